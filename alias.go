@@ -0,0 +1,116 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "fmt"
+    "strings"
+    "sync"
+)
+
+// alias a named rule set
+type alias struct {
+    rules   []string
+    message string
+}
+
+// key: alias name
+// value: the rules of the alias
+// the map is copied on write, so the validations expand the aliases without holding the lock
+var (
+    aliases   = map[string]alias{}
+    aliasesMu sync.RWMutex
+)
+
+// aliasRule a rule expanded from the tag, errDesc is the message of the alias which it comes from
+type aliasRule struct {
+    rule    string
+    errDesc string
+}
+
+// RegisterAlias Register a named rule set which can be used in the valid tag, such as
+// RegisterAlias("Username", "Required;MinSize(6);MaxSize(32);AlphaDash") and `valid:"Username"`,
+// the rules can use the other aliases, the message is used for all the rules of the alias if it is given,
+// the vdesc tag of the field takes precedence over it.
+// If the name is same with exists alias, it will replace the origin one
+func RegisterAlias(name, rules string, message ...string) error {
+    name = strings.TrimSpace(name)
+    if len(name) == 0 || strings.ContainsAny(name, ";,() ") {
+        return fmt.Errorf("invalid alias name: %s", name)
+    }
+    if _, ok := funcs[name]; ok || unFuncs[name] {
+        return fmt.Errorf("alias %s conflicts with the valid function", name)
+    }
+    a := alias{message: strings.Join(message, "")}
+    for _, rule := range splitTop(rules, ';') {
+        if rule = strings.TrimSpace(rule); len(rule) > 0 {
+            a.rules = append(a.rules, rule)
+        }
+    }
+    if len(a.rules) == 0 {
+        return fmt.Errorf("the rules of alias %s can not be empty", name)
+    }
+
+    aliasesMu.Lock()
+    c := make(map[string]alias, len(aliases) + 1)
+    for k, v := range aliases {
+        c[k] = v
+    }
+    c[name] = a
+    if _, err := expandAliasesIn(c, []string{name}, "", nil); err != nil {
+        aliasesMu.Unlock()
+        return err
+    }
+    aliases = c
+    aliasesMu.Unlock()
+    resetPlans()
+    return nil
+}
+
+// expandAliases replace the aliases in the rules by their rules recursively,
+// errDesc is the message of the alias which the rules come from, stack is the aliases being expanded
+func expandAliases(rules []string, errDesc string, stack []string) ([]aliasRule, error) {
+    aliasesMu.RLock()
+    m := aliases
+    aliasesMu.RUnlock()
+    return expandAliasesIn(m, rules, errDesc, stack)
+}
+
+func expandAliasesIn(m map[string]alias, rules []string, errDesc string, stack []string) (expanded []aliasRule, err error) {
+    for _, rule := range rules {
+        rule = strings.TrimSpace(rule)
+        a, ok := m[rule]
+        if !ok {
+            expanded = append(expanded, aliasRule{rule, errDesc})
+            continue
+        }
+        for _, name := range stack {
+            if name == rule {
+                return nil, fmt.Errorf("alias cycle: %s -> %s", strings.Join(stack, " -> "), rule)
+            }
+        }
+        // the message of the outer alias takes precedence over the inner one
+        desc := errDesc
+        if len(desc) == 0 {
+            desc = a.message
+        }
+        var sub []aliasRule
+        if sub, err = expandAliasesIn(m, a.rules, desc, append(stack[:len(stack):len(stack)], rule)); err != nil {
+            return nil, err
+        }
+        expanded = append(expanded, sub...)
+    }
+    return
+}
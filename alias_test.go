@@ -0,0 +1,113 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "testing"
+)
+
+func TestRegisterAlias(t *testing.T) {
+    tests := []struct {
+        name  string
+        rules string
+        ok    bool
+    }{
+        {"TestAliasUser", "Required;MinSize(3);MaxSize(8);AlphaDash", true},
+        {"TestAliasDigits", "Match(/^[0-9]+$/)", true},
+        {"TestAliasCode", "TestAliasDigits;Length(4)", true},
+        {"", "Required", false},
+        {"Test Alias", "Required", false},
+        {"TestAlias(1)", "Required", false},
+        {"TestAliasEmpty", " ; ", false},
+        // the alias can't replace the valid function
+        {"Email", "Required", false},
+        {"Required", "MinSize(1)", false},
+        // the cycles are rejected
+        {"TestAliasSelf", "TestAliasSelf", false},
+        {"TestAliasA", "TestAliasB", true},
+        {"TestAliasB", "Required;TestAliasC", true},
+        {"TestAliasC", "TestAliasA", false},
+    }
+    for _, test := range tests {
+        if err := RegisterAlias(test.name, test.rules); (err == nil) != test.ok {
+            t.Errorf("RegisterAlias(%s, %s) error = %v, want ok %v", test.name, test.rules, err, test.ok)
+        }
+    }
+    // the rejected alias is not registered
+    type form struct {
+        Name string `valid:"TestAliasC"`
+    }
+    if err := (&Validation{}).Valid(form{}); err == nil {
+        t.Error("the rejected alias should not be registered")
+    }
+}
+
+func TestAliasTag(t *testing.T) {
+    if err := RegisterAlias("TestAliasName", "Required;MaxSize(5);AlphaDash"); err != nil {
+        t.Fatal(err)
+    }
+    if err := RegisterAlias("TestAliasPin", "Numeric;Length(4)", "invalid pin"); err != nil {
+        t.Fatal(err)
+    }
+    if err := RegisterAlias("TestAliasLogin", "TestAliasPin", "invalid login"); err != nil {
+        t.Fatal(err)
+    }
+    type form struct {
+        Name  string   `valid:"TestAliasName"`
+        Pin   string   `valid:"TestAliasPin"`
+        Login string   `valid:"TestAliasLogin"`
+        Tags  []string `valid:"Values(TestAliasName)"`
+        Code  string   `valid:"TestAliasPin" vdesc:"the code is wrong"`
+    }
+    ok := form{"beego", "1234", "1234", []string{"a"}, "1234"}
+    tests := []struct {
+        f       form
+        key     string
+        message string
+    }{
+        {ok, "", ""},
+        {form{"", "1234", "1234", nil, "1234"}, "Name.Required", "Name 不能为空"},
+        {form{"a b", "1234", "1234", nil, "1234"}, "Name.AlphaDash", "Name 必须是字母或数字或-或_"},
+        {form{"beego", "12a4", "1234", nil, "1234"}, "Pin.Numeric", "invalid pin"},
+        // the message of the outer alias takes precedence
+        {form{"beego", "1234", "12345", nil, "1234"}, "Login.Length", "invalid login"},
+        {form{"beego", "1234", "1234", []string{"a", "toolong"}, "1234"}, "Tags[1].MaxSize", "Tags[1] 不能长于 5 个字符"},
+        // the vdesc of the field takes precedence
+        {form{"beego", "1234", "1234", nil, "123"}, "Code.Length", "the code is wrong"},
+    }
+    for _, test := range tests {
+        valid := Validation{}
+        if err := valid.Valid(test.f); err != nil && !valid.HasErrors() {
+            t.Fatal(err)
+        }
+        key, message := "", ""
+        if valid.HasErrors() {
+            key, message = valid.Errors[0].Key, valid.Errors[0].Message
+        }
+        if key != test.key || message != test.message {
+            t.Errorf("Valid(%+v) = %s %q, want %s %q", test.f, key, message, test.key, test.message)
+        }
+    }
+
+    // the replaced alias is used by the compiled plans
+    if err := RegisterAlias("TestAliasName", "Required;MaxSize(10)"); err != nil {
+        t.Fatal(err)
+    }
+    valid := Validation{}
+    valid.Valid(form{"a b", "1234", "1234", nil, "1234"})
+    if valid.HasErrors() {
+        t.Errorf("the replaced alias should be used, errors = %v", valid.Errors)
+    }
+}
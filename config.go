@@ -0,0 +1,209 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "encoding/json"
+    "fmt"
+    "os"
+    "reflect"
+    "sort"
+    "strings"
+    "sync"
+)
+
+// FieldRules the rules of a struct field in the rules config file
+type FieldRules struct {
+    // the rules, same as the valid tag, such as Required;Range(1,5000)
+    Rules string `json:"rules"`
+    // the messages, same as the vdesc tag
+    Message string `json:"message,omitempty"`
+    // replace the valid and vdesc tags of the field, by default the rules are applied after the tags
+    Override bool `json:"override,omitempty"`
+}
+
+// ruleSet the loaded rules config
+type ruleSet struct {
+    // increased by every load, the plans compiled by the old config are recompiled
+    version uint64
+    path    string
+    // key: the struct type
+    // value: the rules of the fields
+    types map[reflect.Type]map[string]FieldRules
+}
+
+var (
+    rulesMu sync.RWMutex
+    rules   = &ruleSet{}
+    // key: the type name, both the short name such as Order and the full name such as models.Order
+    // value: the struct type, nil if the short name is used by more than one type
+    rulesTypes = map[string]reflect.Type{}
+)
+
+func currentRules() *ruleSet {
+    rulesMu.RLock()
+    defer rulesMu.RUnlock()
+    return rules
+}
+
+// fields return the rules of the fields of the struct type
+func (r *ruleSet) fields(t reflect.Type) map[string]FieldRules {
+    return r.types[t]
+}
+
+// RegisterRulesType Register the struct types which can be configured by LoadRules, such as
+// RegisterRulesType(reflect.TypeOf(models.Order{})), the fields in the rules file are checked by the types when
+// they are loaded, the short name such as Order can only be used if no other registered type has the same name
+func RegisterRulesType(types ...reflect.Type) error {
+    structs := make([]reflect.Type, len(types))
+    for i, t := range types {
+        if t != nil && t.Kind() == reflect.Ptr {
+            t = t.Elem()
+        }
+        if t == nil || t.Kind() != reflect.Struct || len(t.Name()) == 0 {
+            return fmt.Errorf("%v must be a named struct type", t)
+        }
+        structs[i] = t
+    }
+    rulesMu.Lock()
+    defer rulesMu.Unlock()
+    for _, t := range structs {
+        rulesTypes[t.String()] = t
+        if old, ok := rulesTypes[t.Name()]; ok && old != t {
+            rulesTypes[t.Name()] = nil
+        } else {
+            rulesTypes[t.Name()] = t
+        }
+    }
+    return nil
+}
+
+// lookupRulesType return the registered struct type of the name in the rules file
+func lookupRulesType(name string) (reflect.Type, error) {
+    rulesMu.RLock()
+    defer rulesMu.RUnlock()
+    t, ok := rulesTypes[name]
+    if !ok {
+        return nil, fmt.Errorf("doesn't exist %s type, it must be registered by RegisterRulesType", name)
+    }
+    if t == nil {
+        return nil, fmt.Errorf("%s is used by more than one type, use the name with the package", name)
+    }
+    return t, nil
+}
+
+// checkRulesConfig check the fields exist in the struct type and the rules are valid
+func checkRulesConfig(t reflect.Type, fields map[string]FieldRules) error {
+    for name, fr := range fields {
+        f, ok := t.FieldByName(name)
+        if !ok || len(f.Index) != 1 || len(f.PkgPath) > 0 {
+            return fmt.Errorf("doesn't exist %s field", name)
+        }
+        if len(strings.TrimSpace(fr.Rules)) == 0 && !fr.Override {
+            return fmt.Errorf("%s: the rules can not be empty", name)
+        }
+        vfs, err := parseValidTag(fr.Rules, fr.Message, name)
+        if err != nil {
+            return fmt.Errorf("%s: %v", name, err)
+        }
+        if err = checkFieldRules(t, f, vfs); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// LoadRules Load the rules of the struct types from a json file, such as
+//   {"Order": {"Amount": {"rules": "Range(1,5000)", "message": "invalid amount", "override": true}}}
+// the types must be registered by RegisterRulesType, the fields and the rules are checked by the types,
+// the registered valid functions and aliases, and replace the rules loaded before if they are all valid.
+// It is safe to call while validations are running, the running ones use the old rules
+func LoadRules(path string) error {
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return err
+    }
+    var config map[string]map[string]FieldRules
+    if err = json.Unmarshal(data, &config); err != nil {
+        return err
+    }
+    types := make(map[reflect.Type]map[string]FieldRules, len(config))
+    for typeName, fields := range config {
+        t, err := lookupRulesType(strings.TrimSpace(typeName))
+        if err != nil {
+            return err
+        }
+        if _, ok := types[t]; ok {
+            return fmt.Errorf("the rules of %s are duplicated", t)
+        }
+        if err = checkRulesConfig(t, fields); err != nil {
+            return fmt.Errorf("%s: %v", typeName, err)
+        }
+        types[t] = fields
+    }
+
+    rulesMu.Lock()
+    rules = &ruleSet{version: rules.version + 1, path: path, types: types}
+    rulesMu.Unlock()
+    resetPlans()
+    return nil
+}
+
+// ReloadRules Reload the rules from the file loaded by LoadRules, the rules are not changed if the file is invalid
+func ReloadRules() error {
+    path := currentRules().path
+    if len(path) == 0 {
+        return fmt.Errorf("the rules are not loaded")
+    }
+    return LoadRules(path)
+}
+
+// applyRulesConfig apply the rules of the config to the plan
+func (p *structPlan) applyRulesConfig(t reflect.Type, fields map[string]FieldRules) error {
+    for name, fr := range fields {
+        f, ok := t.FieldByName(name)
+        if !ok || len(f.Index) != 1 {
+            return fmt.Errorf("doesn't exist %s field", name)
+        }
+        vfs, err := parseValidTag(fr.Rules, fr.Message, name)
+        if err != nil {
+            return fmt.Errorf("%s: %v", name, err)
+        }
+        if err = checkFieldRules(t, f, vfs); err != nil {
+            return err
+        }
+
+        var fp *fieldPlan
+        for i := range p.fields {
+            if p.fields[i].index == f.Index[0] {
+                fp = &p.fields[i]
+            }
+        }
+        if fp == nil {
+            p.fields = append(p.fields, fieldPlan{index: f.Index[0], name: name})
+            fp = &p.fields[len(p.fields) - 1]
+        }
+        if fr.Override {
+            fp.vfs = vfs
+        } else {
+            fp.vfs = append(fp.vfs, vfs...)
+        }
+    }
+    // validate the fields in the declared order, same as the tags
+    sort.Slice(p.fields, func(i, j int) bool {
+        return p.fields[i].index < p.fields[j].index
+    })
+    return nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "os"
+    "path/filepath"
+    "reflect"
+    "testing"
+)
+
+type configOrder struct {
+    Amount int `valid:"Min(1)"`
+    Note   string
+    secret string
+}
+
+// loadTestRules write the rules file and load it
+func loadTestRules(t *testing.T, path, data string) error {
+    if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+        t.Fatal(err)
+    }
+    return LoadRules(path)
+}
+
+// validKey return the key of the first error of Valid
+func validKey(t *testing.T, obj interface{}) string {
+    valid := Validation{}
+    if err := valid.Valid(obj); err != nil && !valid.HasErrors() {
+        t.Fatal(err)
+    }
+    if valid.HasErrors() {
+        return valid.Errors[0].Key
+    }
+    return ""
+}
+
+func TestLoadRules(t *testing.T) {
+    if err := RegisterRulesType(reflect.TypeOf(&configOrder{})); err != nil {
+        t.Fatal(err)
+    }
+    dir := t.TempDir()
+    path := filepath.Join(dir, "rules.json")
+    t.Cleanup(func() {
+        loadTestRules(t, path, `{}`)
+    })
+
+    if err := loadTestRules(t, path, `{"configOrder": {"Amount": {"rules": "Max(100)"}, "Note": {"rules": "MaxSize(3)"}}}`); err != nil {
+        t.Fatal(err)
+    }
+    tests := []struct {
+        o   configOrder
+        key string
+    }{
+        {configOrder{Amount: 10, Note: "abc"}, ""},
+        // the rules are applied after the tags
+        {configOrder{Amount: 0}, "Amount.Min"},
+        {configOrder{Amount: 101}, "Amount.Max"},
+        {configOrder{Amount: 10, Note: "abcd"}, "Note.MaxSize"},
+    }
+    for _, test := range tests {
+        if key := validKey(t, test.o); key != test.key {
+            t.Errorf("Valid(%+v) error key = %s, want %s", test.o, key, test.key)
+        }
+    }
+
+    // the invalid files don't replace the loaded rules
+    invalids := []string{
+        `{"configOrder": {"Price": {"rules": "Min(1)"}}}`,
+        `{"configOrder": {"secret": {"rules": "Required"}}}`,
+        `{"configOrder": {"Note": {"rules": "Nope"}}}`,
+        `{"configOrder": {"Note": {"rules": ""}}}`,
+        `{"configOrder": {"Note": {"rules": "Enum(TestConfigNope)"}}}`,
+        `{"NopeOrder": {"Note": {"rules": "Required"}}}`,
+        `{"configOrder": {}, "validation.configOrder": {}}`,
+        `not json`,
+    }
+    for _, data := range invalids {
+        if err := loadTestRules(t, path, data); err == nil {
+            t.Errorf("LoadRules(%s) should return the error", data)
+        }
+    }
+    if key := validKey(t, configOrder{Amount: 101}); key != "Amount.Max" {
+        t.Errorf("the old rules should be kept, error key = %s", key)
+    }
+    if err := LoadRules(filepath.Join(dir, "missing.json")); err == nil {
+        t.Error("LoadRules should return the error of the missing file")
+    }
+
+    // the override rules replace the tags
+    if err := loadTestRules(t, path, `{"validation.configOrder": {"Amount": {"rules": "Range(5,10)", "message": "bad amount", "override": true}}}`); err != nil {
+        t.Fatal(err)
+    }
+    valid := Validation{}
+    valid.Valid(configOrder{Amount: 1})
+    if !valid.HasErrors() || valid.Errors[0].Key != "Amount.Range" || valid.Errors[0].Message != "bad amount" {
+        t.Errorf("the override rules should be applied, errors = %v", valid.Errors)
+    }
+    if key := validKey(t, configOrder{Amount: 5, Note: "abcd"}); key != "" {
+        t.Errorf("the rules of Note should be replaced, error key = %s", key)
+    }
+}
+
+func TestReloadRules(t *testing.T) {
+    if err := RegisterRulesType(reflect.TypeOf(configOrder{})); err != nil {
+        t.Fatal(err)
+    }
+    path := filepath.Join(t.TempDir(), "rules.json")
+    t.Cleanup(func() {
+        loadTestRules(t, path, `{}`)
+    })
+    if err := loadTestRules(t, path, `{"configOrder": {"Note": {"rules": "Required"}}}`); err != nil {
+        t.Fatal(err)
+    }
+    if key := validKey(t, configOrder{Amount: 1}); key != "Note.Required" {
+        t.Errorf("error key = %s, want Note.Required", key)
+    }
+
+    if err := os.WriteFile(path, []byte(`{"configOrder": {"Note": {"rules": "MaxSize(1)"}}}`), 0644); err != nil {
+        t.Fatal(err)
+    }
+    if err := ReloadRules(); err != nil {
+        t.Fatal(err)
+    }
+    if key := validKey(t, configOrder{Amount: 1, Note: "ab"}); key != "Note.MaxSize" {
+        t.Errorf("the reloaded rules should be applied, error key = %s", key)
+    }
+
+    if err := os.WriteFile(path, []byte(`{"configOrder": {"Nope": {"rules": "Required"}}}`), 0644); err != nil {
+        t.Fatal(err)
+    }
+    if err := ReloadRules(); err == nil {
+        t.Error("ReloadRules should return the error of the invalid file")
+    }
+    if key := validKey(t, configOrder{Amount: 1, Note: "ab"}); key != "Note.MaxSize" {
+        t.Errorf("the old rules should be kept, error key = %s", key)
+    }
+}
+
+func TestRegisterRulesType(t *testing.T) {
+    for _, typ := range []reflect.Type{nil, reflect.TypeOf(1), reflect.TypeOf(struct{ A int }{})} {
+        if err := RegisterRulesType(typ); err == nil {
+            t.Errorf("RegisterRulesType(%v) should return the error", typ)
+        }
+    }
+}
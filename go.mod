@@ -0,0 +1,9 @@
+module github.com/skyrunner2012/validation
+
+go 1.25.0
+
+require (
+	github.com/rivo/uniseg v0.4.7
+	golang.org/x/net v0.57.0
+	golang.org/x/text v0.40.0
+)
@@ -0,0 +1,202 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "fmt"
+)
+
+// MessageTmplsEn store the english validate template
+var MessageTmplsEn = map[string]string{
+    "Required":            "%s can not be empty",
+    "Min":                 "%s minimum is %d",
+    "Max":                 "%s maximum is %d",
+    "Range":               "%s range is %d to %d",
+    "MinSize":             "%s minimum size is %d",
+    "MaxSize":             "%s maximum size is %d",
+    "Length":              "%s required length is %d",
+    "Alpha":               "%s must be valid alpha characters",
+    "Numeric":             "%s must be valid numeric characters",
+    "AlphaNumeric":        "%s must be valid alpha or numeric characters",
+    "Match":               "%s must match %s",
+    "NoMatch":             "%s must not match %s",
+    "AlphaDash":           "%s must be valid alpha or numeric or dash(-_) characters",
+    "Email":               "%s must be a valid email address",
+    "IP":                  "%s must be a valid ip address",
+    "Base64":              "%s must be valid base64 characters",
+    "Mobile":              "%s must be valid mobile number",
+    "Tel":                 "%s must be valid telephone number",
+    "Phone":               "%s must be valid telephone or mobile phone number",
+    "ZipCode":             "%s must be valid zipcode",
+    "In":                  "%s must be one of %s",
+    "OneOf":               "%s must be one of %s",
+    "InFold":              "%s must be one of %s",
+    "NotIn":               "%s must not be any of %s",
+    "NotInFold":           "%s must not be any of %s",
+    "Enum":                "%s must be one of %s",
+    "Contains":            "%s must contain %s",
+    "ContainsAny":         "%s must contain at least one of the characters %s",
+    "Excludes":            "%s must not contain %s",
+    "ExcludesRune":        "%s must not contain the character %s",
+    "StartsWith":          "%s must start with %s",
+    "EndsWith":            "%s must end with %s",
+    "ASCII":               "%s must only contain ascii characters",
+    "PrintableASCII":      "%s must only contain printable ascii characters",
+    "Lowercase":           "%s must not contain upper case letters",
+    "Uppercase":           "%s must not contain lower case letters",
+    "NoWhitespace":        "%s must not contain white space",
+    "UnicodeAlpha":        "%s must only contain letters",
+    "UnicodeAlphaNumeric": "%s must only contain letters or digits",
+    "Han":                 "%s must only contain chinese characters",
+    "ChineseName":         "%s must be a valid chinese name",
+    "Script":              "%s must only contain characters of %s scripts",
+    "IPv4":                "%s must be a valid ipv4 address",
+    "IPv6":                "%s must be a valid ipv6 address",
+    "CIDR":                "%s must be a valid CIDR notation",
+    "CIDRv4":              "%s must be a valid ipv4 CIDR notation",
+    "CIDRv6":              "%s must be a valid ipv6 CIDR notation",
+    "IPInSubnet":          "%s must be an ip address in the subnet %s",
+    "PrivateIP":           "%s must be a private ip address",
+    "PublicIP":            "%s must be a public ip address",
+    "TCPAddr":             "%s must be a valid host:port address",
+    "Port":                "%s must be a valid port number",
+    "MAC":                 "%s must be a valid MAC address",
+    "URL":                 "%s must be a valid url",
+    "URLScheme":           "%s must be a valid url with scheme %s",
+    "URLHost":             "%s must be a valid url with host %s",
+    "URLSchemeHost":       "%s must be a valid url with scheme %s and host %s",
+    "URI":                 "%s must be a valid uri",
+    "RelativeURL":         "%s must be a valid relative url",
+    "Hostname":            "%s must be a valid host name",
+    "FQDN":                "%s must be a valid fully qualified domain name",
+    "DomainWithTLD":       "%s must be a valid domain name",
+    "DataURI":             "%s must be a valid data uri",
+    "IDCard":              "%s must be a valid chinese resident id card number",
+    "USCC":                "%s must be a valid unified social credit code",
+    "OrgCode":             "%s must be a valid organization code",
+    "BankCard":            "%s must be a valid bank card number",
+    "LicensePlate":        "%s must be a valid vehicle license plate",
+    "E164":                "%s must be a valid E.164 phone number",
+    "PhoneRegion":         "%s must be a valid phone number of %s",
+    "CreditCard":          "%s must be a valid credit card number",
+    "CreditCardBrand":     "%s must be a valid credit card number of %s",
+    "IBAN":                "%s must be a valid IBAN",
+    "BIC":                 "%s must be a valid BIC/SWIFT code",
+    "Currency":            "%s must be a valid ISO 4217 currency code",
+    "Money":               "%s must be a valid amount with at most %d decimal places",
+    "DateFormat":          "%s must be a time in the format %s",
+    "Before":              "%s must be before %s",
+    "After":               "%s must be after %s",
+    "Between":             "%s must be between %s and %s",
+    "MinAge":              "%s age must be at least %d",
+    "MaxAge":              "%s age must be at most %d",
+    "UUID":                "%s must be a valid UUID",
+    "UUIDVersion":         "%s must be a UUID of version %s",
+    "ULID":                "%s must be a valid ULID",
+    "Semver":              "%s must be a valid semantic version",
+    "Hexadecimal":         "%s must be a hexadecimal number",
+    "HexColor":            "%s must be a hex color",
+    "RGB":                 "%s must be a valid rgb color",
+    "RGBA":                "%s must be a valid rgba color",
+    "ISBN10":              "%s must be a valid ISBN-10",
+    "ISBN13":              "%s must be a valid ISBN-13",
+    "ObjectID":            "%s must be a valid ObjectID",
+    "Base64MinBytes":      "%s must be valid base64 of at least %d bytes",
+    "Base64MaxBytes":      "%s must be valid base64 of at most %d bytes",
+    "Base64Range":         "%s must be valid base64 of %d to %d bytes",
+    "IsJSON":              "%s must be valid JSON",
+    "JSONObject":          "%s must be a JSON object",
+    "IsXML":               "%s must be well-formed XML",
+    "CSVRow":              "%s must be a single CSV record",
+    "CSVRowColumns":       "%s must be a single CSV record of %d columns",
+    "IsRegexp":            "%s must be a valid regular expression",
+    "JSONSchemaLite":      "%s must match the JSON schema %s",
+    "Latitude":            "%s must be a latitude between -90 and 90",
+    "Longitude":           "%s must be a longitude between -180 and 180",
+    "LatLng":              "%s must be a coordinate of \"latitude,longitude\"",
+    "Timezone":            "%s must be a valid IANA time zone",
+    "CountryAlpha2":       "%s must be an ISO 3166-1 alpha-2 country code",
+    "CountryAlpha3":       "%s must be an ISO 3166-1 alpha-3 country code",
+    "Language":            "%s must be an ISO 639-1 language code",
+    "RegionCodeFormat":    "%s must be in the format of administrative division code of china",
+    "PasswordPolicy":      "%s doesn't match the password policy",
+    "PasswordMinLength":   "%s must be at least %d characters",
+    "PasswordLower":       "%s must contain a lowercase letter",
+    "PasswordUpper":       "%s must contain an uppercase letter",
+    "PasswordDigit":       "%s must contain a digit",
+    "PasswordSymbol":      "%s must contain a symbol",
+    "PasswordClasses":     "%s must contain at least %d of uppercase letters, lowercase letters, digits and symbols",
+    "PasswordRepeat":      "%s must not contain more than %d same characters in a row",
+    "PasswordSequence":    "%s must not contain %d or more sequential characters, such as 1234",
+    "PasswordUsername":    "%s must not contain the username",
+    "MinSizeBytes":        "%s minimum size is %d bytes",
+    "MaxSizeBytes":        "%s maximum size is %d bytes",
+    "LengthBytes":         "%s required length is %d bytes",
+    "MinSizeWidth":        "%s minimum display width is %d",
+    "MaxSizeWidth":        "%s maximum display width is %d",
+    "LengthWidth":         "%s required display width is %d",
+    "Unique":              "%s must not contain duplicate items",
+    "UniqueField":         "%s must not contain items with duplicate %s",
+    "ContainsItem":        "%s must contain %s",
+    "SubsetOf":            "%s items must be in %s",
+    "MinItems":            "%s must contain at least %d items",
+    "MaxItems":            "%s must contain at most %d items",
+    "Keys":                "%s must be a map",
+    "Values":              "%s must be a map, slice or array",
+    "Or":                  "%s must satisfy one of: %s",
+    "And":                 "%s must satisfy all of: %s",
+    "Not":                 "%s must not satisfy: %s",
+    "Expr":                "%s must satisfy: %s",
+}
+
+// key: locale name
+// value: the validate templates of the locale
+var locales = map[string]map[string]string{
+    "zh": copyTmpls(MessageTmpls),
+    "en": MessageTmplsEn,
+}
+
+func copyTmpls(tmpls map[string]string) map[string]string {
+    c := make(map[string]string, len(tmpls))
+    for name := range tmpls {
+        c[name] = tmpls[name]
+    }
+    return c
+}
+
+// the separators of the messages combined by Or, And and Not, they are set by SetLocale
+var (
+    OrSeparator  = "；"
+    AndSeparator = "，且"
+)
+
+// key: locale name
+// value: the separators of Or and And of the locale
+var localeSeparators = map[string][2]string{
+    "zh": {"；", "，且"},
+    "en": {"; ", ", and "},
+}
+
+// SetLocale Set the default messages to the shipped locale, "zh" or "en"
+// the messages set by SetDefaultMessage before will be overwritten
+func SetLocale(locale string) error {
+    tmpls, ok := locales[locale]
+    if !ok {
+        return fmt.Errorf("doesn't exist %s locale", locale)
+    }
+    SetDefaultMessage(tmpls)
+    OrSeparator, AndSeparator = localeSeparators[locale][0], localeSeparators[locale][1]
+    return nil
+}
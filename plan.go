@@ -0,0 +1,251 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "fmt"
+    "reflect"
+    "strings"
+    "sync"
+)
+
+// fieldPlan the compiled tags of one struct field
+type fieldPlan struct {
+    index      int
+    name       string
+    defValue   *reflect.Value
+    sanitizers []SanitizeFunc
+    vfs        []ValidFunc
+}
+
+// structPlan the compiled tags of one struct type
+// it is compiled once by the first validation of the type, and cached
+type structPlan struct {
+    fields []fieldPlan
+    // the version of the rules config which the plan is compiled by
+    version uint64
+}
+
+// key: reflect.Type of the struct
+// value: *structPlan
+var plans sync.Map
+
+// resetPlans drop all the cached plans, should be called when the funcs
+// or sanitizers which may be used by a plan are changed
+func resetPlans() {
+    plans.Range(func(k, _ interface{}) bool {
+        plans.Delete(k)
+        return true
+    })
+}
+
+func getPlan(t reflect.Type) (*structPlan, error) {
+    rs := currentRules()
+    // the plan compiled by the old rules config is recompiled, even if it is stored after the config is reloaded
+    if p, ok := plans.Load(t); ok && p.(*structPlan).version == rs.version {
+        return p.(*structPlan), nil
+    }
+    p, err := compilePlan(t, rs)
+    if err != nil {
+        return nil, err
+    }
+    plans.Store(t, p)
+    return p, nil
+}
+
+func compilePlan(t reflect.Type, rs *ruleSet) (p *structPlan, err error) {
+    p = &structPlan{version: rs.version}
+    for i := 0; i < t.NumField(); i++ {
+        f := t.Field(i)
+        fp := fieldPlan{index: i, name: f.Name}
+        if fp.defValue, err = getDefaultValue(f); err != nil {
+            return nil, fmt.Errorf("%s: %v", f.Name, err)
+        }
+        if fp.sanitizers, err = getSanitizeFuncs(f); err != nil {
+            return nil, err
+        }
+        if fp.vfs, err = getValidFuncs(f); err != nil {
+            return nil, err
+        }
+        if err = checkFieldRules(t, f, fp.vfs); err != nil {
+            return nil, err
+        }
+        if fp.defValue == nil && len(fp.sanitizers) == 0 && len(fp.vfs) == 0 {
+            continue
+        }
+        p.fields = append(p.fields, fp)
+    }
+    if fields := rs.fields(t); len(fields) > 0 {
+        if err = p.applyRulesConfig(t, fields); err != nil {
+            return nil, err
+        }
+    }
+    return
+}
+
+// key: the name of the valid function
+// value: check the parameters of the rule against the type of the field when the plan is compiled,
+// such as the field of Unique(SKU) must exist in the elements, st is the struct type of the field,
+// it is nil if the rule doesn't belong to a struct, such as the rules of Schema
+var fieldCheckers = map[string]func(st, t reflect.Type, params []interface{}) error{
+    "Unique": func(st, t reflect.Type, params []interface{}) error {
+        field, _ := params[0].(UniqueField)
+        return checkUniqueField(t, field)
+    },
+    "Enum": func(st, t reflect.Type, params []interface{}) error {
+        name, _ := params[0].(string)
+        if _, ok := getEnum(name); !ok {
+            return fmt.Errorf("doesn't exist %s enum", name)
+        }
+        return nil
+    },
+    "JSONSchemaLite": func(st, t reflect.Type, params []interface{}) error {
+        path, _ := params[0].(string)
+        _, err := loadJSONSchema(path)
+        return err
+    },
+    "PasswordPolicy": func(st, t reflect.Type, params []interface{}) error {
+        opts, _ := params[0].(PasswordOptions)
+        return checkPasswordUserField(st, opts.UserField)
+    },
+}
+
+// checkFieldRules check the rules of the field of the struct type st by fieldCheckers
+func checkFieldRules(st reflect.Type, f reflect.StructField, vfs []ValidFunc) error {
+    if err := checkRulesOf(st, f.Type, vfs); err != nil {
+        return fmt.Errorf("%s: %v", f.Name, err)
+    }
+    return nil
+}
+
+// checkRulesOf check the rules against the type t, the nested rules are checked against
+// the type of the keys or the values, such as Values(Enum(Status)) or Or(Enum(A),Enum(B))
+func checkRulesOf(st, t reflect.Type, vfs []ValidFunc) error {
+    for _, vf := range vfs {
+        if check, ok := fieldCheckers[vf.Name]; ok {
+            if err := check(st, t, vf.Params); err != nil {
+                return err
+            }
+        }
+        for _, param := range vf.Params {
+            rules, ok := param.(Rules)
+            if !ok {
+                continue
+            }
+            if nt := nestedType(t, vf.Name); nt != nil {
+                if err := checkRulesOf(st, nt, rules); err != nil {
+                    return err
+                }
+            }
+        }
+    }
+    return nil
+}
+
+// nestedType return the type which the nested rules of the valid function are applied to,
+// it returns nil if it is unknown, such as Values of a non-collection type
+func nestedType(t reflect.Type, name string) reflect.Type {
+    et := t
+    if et.Kind() == reflect.Ptr {
+        et = et.Elem()
+    }
+    switch name {
+    case "Keys":
+        if et.Kind() == reflect.Map {
+            return et.Key()
+        }
+        return nil
+    case "Values":
+        switch et.Kind() {
+        case reflect.Map, reflect.Slice, reflect.Array:
+            return et.Elem()
+        }
+        return nil
+    }
+    return t
+}
+
+// prepare fill the default values and apply the sanitizers before validation
+func (p *structPlan) prepare(objV reflect.Value) error {
+    for _, fp := range p.fields {
+        fv := objV.Field(fp.index)
+        if fp.defValue != nil {
+            if err := setDefault(fv, *fp.defValue); err != nil {
+                return fmt.Errorf("%s: %v", fp.name, err)
+            }
+        }
+        if len(fp.sanitizers) > 0 {
+            if err := sanitizeValue(fv, fp.sanitizers); err != nil {
+                return fmt.Errorf("%s: %v", fp.name, err)
+            }
+        }
+    }
+    return nil
+}
+
+// getDefaultValue parse the default tag to the type of the field
+// the default tag of the unsupported types is skipped, it may be used by other tools, such as swagger
+func getDefaultValue(f reflect.StructField) (*reflect.Value, error) {
+    tag, ok := f.Tag.Lookup(DefaultTag)
+    if !ok {
+        return nil, nil
+    }
+    t := f.Type
+    if t.Kind() == reflect.Ptr {
+        t = t.Elem()
+    }
+    if !supportDefault(t) {
+        return nil, nil
+    }
+    i, err := parseParam(t, strings.TrimSpace(tag))
+    if err != nil {
+        return nil, err
+    }
+    v := reflect.ValueOf(i)
+    return &v, nil
+}
+
+// supportDefault whether the default tag can be parsed to the type
+func supportDefault(t reflect.Type) bool {
+    if t == timeType {
+        return true
+    }
+    switch t.Kind() {
+    case reflect.String, reflect.Bool,
+        reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+        reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+        reflect.Float32, reflect.Float64:
+        return true
+    }
+    return false
+}
+
+// setDefault set the field to the default value if it is zero value
+func setDefault(fv reflect.Value, def reflect.Value) error {
+    if !fv.IsZero() {
+        return nil
+    }
+    if !fv.CanSet() {
+        return fmt.Errorf("can not set default value, obj must be a struct pointer")
+    }
+    if fv.Kind() == reflect.Ptr {
+        ptr := reflect.New(fv.Type().Elem())
+        ptr.Elem().Set(def)
+        fv.Set(ptr)
+        return nil
+    }
+    fv.Set(def)
+    return nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "reflect"
+    "testing"
+    "time"
+)
+
+type defaultAddress struct {
+    City string
+}
+
+type defaultUser struct {
+    Name    string         `default:"anon" valid:"Required"`
+    Age     int8           `default:"18"`
+    Score   uint           `default:"7"`
+    Rate    float64        `default:"1.5"`
+    Active  bool           `default:"true"`
+    Timeout time.Duration  `default:"1m30s"`
+    Since   time.Time      `default:"2020-01-02"`
+    Limit   *int           `default:"3"`
+    Count   int            `default:"9"`
+    // the default tag of the struct is used by other tools, such as swagger
+    Address defaultAddress `default:"{}"`
+    Tags    []string       `default:"a,b"`
+}
+
+func TestDefault(t *testing.T) {
+    u := defaultUser{Count: 1}
+    if err := (&Validation{}).Valid(&u); err != nil {
+        t.Fatalf("Valid error = %v", err)
+    }
+    since := time.Date(2020, 1, 2, 0, 0, 0, 0, time.Local)
+    if u.Name != "anon" || u.Age != 18 || u.Score != 7 || u.Rate != 1.5 || !u.Active ||
+        u.Timeout != 90 * time.Second || !u.Since.Equal(since) || u.Limit == nil || *u.Limit != 3 {
+        t.Errorf("the default values = %+v", u)
+    }
+    // the non-zero values and the unsupported types are not changed
+    if u.Count != 1 || u.Address.City != "" || u.Tags != nil {
+        t.Errorf("the default values = %+v", u)
+    }
+
+    type invalid struct {
+        N int `default:"x"`
+    }
+    tests := []struct {
+        obj interface{}
+        ok  bool
+    }{
+        {&invalid{N: 1}, false},
+        // the zero value field of struct value can not be set
+        {defaultUser{}, false},
+        {defaultUser{Name: "a", Age: 1, Score: 1, Rate: 1, Active: true, Timeout: 1, Since: since, Limit: new(int), Count: 1}, true},
+    }
+    for _, test := range tests {
+        if err := (&Validation{}).Valid(test.obj); (err == nil) != test.ok {
+            t.Errorf("Valid(%T) error = %v, want ok %v", test.obj, err, test.ok)
+        }
+    }
+}
+
+func TestPlanCache(t *testing.T) {
+    typ := reflect.TypeOf(defaultUser{})
+    p1, err := getPlan(typ)
+    if err != nil {
+        t.Fatal(err)
+    }
+    p2, _ := getPlan(typ)
+    if p1 != p2 {
+        t.Error("the plan should be reused")
+    }
+    // the plans are recompiled after the sanitizers are changed
+    if err = AddSanitizer("TestPlanCache", func(s string) string { return s }); err != nil {
+        t.Fatal(err)
+    }
+    p3, _ := getPlan(typ)
+    if p3 == p1 {
+        t.Error("the plan should be recompiled after AddSanitizer")
+    }
+    if p4, _ := getPlan(typ); p4 != p3 {
+        t.Error("the recompiled plan should be reused")
+    }
+
+    type invalid struct {
+        N int `default:"x"`
+    }
+    for i := 0; i < 2; i++ {
+        if _, err = getPlan(reflect.TypeOf(invalid{})); err == nil {
+            t.Error("the invalid plan should not be cached")
+        }
+    }
+}
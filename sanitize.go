@@ -0,0 +1,128 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "fmt"
+    "reflect"
+    "strings"
+
+    "golang.org/x/text/unicode/norm"
+)
+
+// SanitizeFunc is for sanitize a string value before validation
+type SanitizeFunc func(s string) string
+
+// key: sanitizer name
+// value: the sanitize function
+var sanitizers = map[string]SanitizeFunc{
+    "Trim":           strings.TrimSpace,
+    "Lower":          strings.ToLower,
+    "Upper":          strings.ToUpper,
+    "CollapseSpace":  collapseSpace,
+    "StripNonDigits": stripNonDigits,
+    "NFC":            norm.NFC.String,
+    "HalfWidth":      toHalfWidth,
+}
+
+// AddSanitizer Add a custom sanitizer which can be used in the sanitize tag
+// If the name is same with exists sanitizer, it will replace the origin one
+func AddSanitizer(name string, f SanitizeFunc) error {
+    if len(strings.TrimSpace(name)) == 0 || strings.ContainsAny(name, "; ") {
+        return fmt.Errorf("invalid sanitizer name: %s", name)
+    }
+    if f == nil {
+        return fmt.Errorf("sanitizer %s is nil", name)
+    }
+    sanitizers[name] = f
+    resetPlans()
+    return nil
+}
+
+// collapseSpace trim the string and replace every run of white space by a single space
+func collapseSpace(s string) string {
+    return strings.Join(strings.Fields(s), " ")
+}
+
+// stripNonDigits remove all the characters except 0-9
+func stripNonDigits(s string) string {
+    return strings.Map(func(r rune) rune {
+        if '0' <= r && r <= '9' {
+            return r
+        }
+        return -1
+    }, s)
+}
+
+// toHalfWidth convert full-width ascii characters and the ideographic space
+// (which are common in chinese input method) to half-width
+func toHalfWidth(s string) string {
+    return strings.Map(func(r rune) rune {
+        switch {
+        case r == '　':
+            return ' '
+        case '！' <= r && r <= '～':
+            return r - 0xFEE0
+        }
+        return r
+    }, s)
+}
+
+func getSanitizeFuncs(f reflect.StructField) (sfs []SanitizeFunc, err error) {
+    tag := strings.TrimSpace(f.Tag.Get(SanitizeTag))
+    if len(tag) == 0 {
+        return
+    }
+    // only the string and *string fields can be sanitized, it is reported once when the plan is compiled
+    t := f.Type
+    if t.Kind() == reflect.Ptr {
+        t = t.Elem()
+    }
+    if t.Kind() != reflect.String {
+        err = fmt.Errorf("%s: sanitize does not support %s", f.Name, t.Kind().String())
+        return
+    }
+    for _, name := range strings.Split(tag, ";") {
+        name = strings.TrimSpace(name)
+        if len(name) == 0 {
+            continue
+        }
+        sf, ok := sanitizers[name]
+        if !ok {
+            err = fmt.Errorf("doesn't exist %s sanitizer", name)
+            return
+        }
+        sfs = append(sfs, sf)
+    }
+    return
+}
+
+func sanitizeValue(fv reflect.Value, sfs []SanitizeFunc) error {
+    if fv.Kind() == reflect.Ptr {
+        if fv.IsNil() {
+            return nil
+        }
+        fv = fv.Elem()
+    }
+    if !fv.CanSet() {
+        return fmt.Errorf("can not sanitize the field, obj must be a struct pointer")
+    }
+    s := fv.String()
+    for _, sf := range sfs {
+        s = sf(s)
+    }
+    fv.SetString(s)
+    return nil
+}
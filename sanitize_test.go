@@ -0,0 +1,88 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "reflect"
+    "testing"
+)
+
+type sanitizeUser struct {
+    Email string  `sanitize:"Trim;Lower" valid:"Email"`
+    Name  string  `sanitize:"HalfWidth;CollapseSpace"`
+    Code  string  `sanitize:"Trim;StripNonDigits"`
+    Tel   *string `sanitize:"StripNonDigits"`
+}
+
+func TestSanitize(t *testing.T) {
+    tel := "010-1234 5678"
+    tests := []struct {
+        u    sanitizeUser
+        want sanitizeUser
+    }{
+        {sanitizeUser{"  FOO@Bar.com ", "ＡＢＣ　  d", " a1b2 ", &tel}, sanitizeUser{"foo@bar.com", "ABC d", "12", &tel}},
+        // the full-width spaces are converted by HalfWidth before CollapseSpace
+        {sanitizeUser{"a@b.cn", "　x　　y　", "", nil}, sanitizeUser{"a@b.cn", "x y", "", nil}},
+    }
+    for _, test := range tests {
+        u := test.u
+        if err := (&Validation{}).Valid(&u); err != nil {
+            t.Fatalf("Valid(%v) error = %v", test.u, err)
+        }
+        if u.Email != test.want.Email || u.Name != test.want.Name || u.Code != test.want.Code {
+            t.Errorf("sanitize %v = %v, want %v", test.u, u, test.want)
+        }
+        // the sanitizers are idempotent, the sanitized values are not changed again
+        again := u
+        if err := (&Validation{}).Valid(&again); err != nil {
+            t.Fatalf("Valid(%v) error = %v", u, err)
+        }
+        if again != u {
+            t.Errorf("sanitize %v again = %v", u, again)
+        }
+    }
+    if tel != "01012345678" {
+        t.Errorf("sanitize the *string field = %s, want 01012345678", tel)
+    }
+}
+
+func TestSanitizeTag(t *testing.T) {
+    type unknown struct {
+        A string `sanitize:"Nope"`
+    }
+    type notString struct {
+        A int `sanitize:"Trim"`
+    }
+    tests := []struct {
+        obj interface{}
+        ok  bool
+    }{
+        {&sanitizeUser{Email: "a@b.cn"}, true},
+        // the struct value can not be sanitized
+        {sanitizeUser{Email: "a@b.cn"}, false},
+        {&unknown{}, false},
+        {&notString{}, false},
+    }
+    for _, test := range tests {
+        if err := (&Validation{}).Valid(test.obj); (err == nil) != test.ok {
+            t.Errorf("Valid(%T) error = %v, want ok %v", test.obj, err, test.ok)
+        }
+    }
+
+    // the unsupported kind is reported by the plan, even if the field is zero value
+    if _, err := getPlan(reflect.TypeOf(notString{})); err == nil {
+        t.Error("the sanitize tag of int field should be reported when the plan is compiled")
+    }
+}
@@ -0,0 +1,225 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "math"
+    "reflect"
+    "sort"
+    "strconv"
+    "strings"
+)
+
+// Schema the rules of a dynamic payload, such as the map[string]interface{} decoded from JSON,
+// key: the path of the value, such as name, address.city, items[0], items[*].sku or scores.*,
+// [*] and * match all the elements of a slice or all the values of a map
+// value: the rules, same as the valid tag, such as Required;MaxSize(32)
+// the rules are parsed once when the schema is created
+type Schema struct {
+    fields []schemaField
+}
+
+type schemaField struct {
+    path  string
+    steps []pathStep
+    rules Rules
+}
+
+// pathStep a step of the path, the key of a map, the index of a slice, or all of them
+type pathStep struct {
+    key      string
+    index    int
+    isIndex  bool
+    wildcard bool
+}
+
+// schemaItem a value matched by the path, the path has the indexes and keys instead of the wildcards
+type schemaItem struct {
+    path  string
+    value interface{}
+}
+
+// the type of the values in the dynamic payload
+var valueType = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// NewSchema create a schema from the rules of the paths
+func NewSchema(rules map[string]string) (*Schema, error) {
+    paths := make([]string, 0, len(rules))
+    for path := range rules {
+        paths = append(paths, path)
+    }
+    // validate in a stable order, so the first error is always the same one
+    sort.Strings(paths)
+
+    s := &Schema{}
+    for _, path := range paths {
+        steps, err := parsePath(path)
+        if err != nil {
+            return nil, err
+        }
+        fieldRules, err := parseRules(splitTop(rules[path], ';'))
+        if err != nil {
+            return nil, fmt.Errorf("%s: %v", path, err)
+        }
+        // the type of the dynamic values is unknown, so only the checks which don't depend on it work
+        if err = checkRulesOf(nil, valueType, fieldRules); err != nil {
+            return nil, fmt.Errorf("%s: %v", path, err)
+        }
+        s.fields = append(s.fields, schemaField{strings.TrimSpace(path), steps, fieldRules})
+    }
+    return s, nil
+}
+
+// parsePath parse the path, such as items[*].sku or scores.*
+func parsePath(path string) (steps []pathStep, err error) {
+    path = strings.TrimSpace(path)
+    if len(path) == 0 {
+        return nil, fmt.Errorf("the path can not be empty")
+    }
+    for _, segment := range strings.Split(path, ".") {
+        name := segment
+        if index := strings.Index(segment, "["); index >= 0 {
+            name = segment[:index]
+        }
+        switch name {
+        case "":
+            if len(name) == len(segment) {
+                return nil, fmt.Errorf("invalid path: %s", path)
+            }
+        case "*":
+            steps = append(steps, pathStep{wildcard: true})
+        default:
+            steps = append(steps, pathStep{key: name})
+        }
+        // the indexes, such as [0] or [*]
+        for rest := segment[len(name):]; len(rest) > 0; {
+            end := strings.Index(rest, "]")
+            if rest[0] != '[' || end == -1 {
+                return nil, fmt.Errorf("invalid path: %s", path)
+            }
+            if index := rest[1:end]; index == "*" {
+                steps = append(steps, pathStep{wildcard: true})
+            } else if n, err := strconv.Atoi(index); err == nil && n >= 0 {
+                steps = append(steps, pathStep{index: n, isIndex: true})
+            } else {
+                return nil, fmt.Errorf("invalid path: %s", path)
+            }
+            rest = rest[end + 1:]
+        }
+    }
+    return
+}
+
+// resolve return the values matched by the steps, the missing values are nil,
+// the wildcards of the missing values match nothing
+func resolve(value interface{}, steps []pathStep, path string) []schemaItem {
+    if len(steps) == 0 {
+        return []schemaItem{{path, schemaValue(value)}}
+    }
+    step := steps[0]
+    rv := reflect.ValueOf(value)
+    for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+        rv = rv.Elem()
+    }
+    isMap := rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String
+    isSlice := rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array
+
+    switch {
+    case step.wildcard:
+        var items []schemaItem
+        if isMap {
+            for _, k := range sortedKeys(rv) {
+                items = append(items, resolve(rv.MapIndex(k).Interface(), steps[1:], joinPath(path, k.String()))...)
+            }
+        } else if isSlice {
+            for i := 0; i < rv.Len(); i++ {
+                items = append(items, resolve(rv.Index(i).Interface(), steps[1:], fmt.Sprintf("%s[%d]", path, i))...)
+            }
+        }
+        return items
+    case step.isIndex:
+        var elem interface{}
+        if isSlice && step.index < rv.Len() {
+            elem = rv.Index(step.index).Interface()
+        }
+        return resolve(elem, steps[1:], fmt.Sprintf("%s[%d]", path, step.index))
+    default:
+        var elem interface{}
+        if isMap {
+            if ev := rv.MapIndex(reflect.ValueOf(step.key).Convert(rv.Type().Key())); ev.IsValid() {
+                elem = ev.Interface()
+            }
+        }
+        return resolve(elem, steps[1:], joinPath(path, step.key))
+    }
+}
+
+func joinPath(path, key string) string {
+    if len(path) == 0 {
+        return key
+    }
+    return path + "." + key
+}
+
+// schemaValue convert the numbers decoded from JSON to int if they are integers, so Min, Max and Range work
+func schemaValue(value interface{}) interface{} {
+    switch n := value.(type) {
+    case float64:
+        if n == math.Trunc(n) && math.Abs(n) <= 1 << 53 {
+            return int(n)
+        }
+    case json.Number:
+        if i, err := n.Int64(); err == nil {
+            return int(i)
+        }
+        if f, err := n.Float64(); err == nil {
+            return f
+        }
+    }
+    return value
+}
+
+// ValidSchema Validate a dynamic payload, such as map[string]interface{} or []interface{}, by the schema,
+// the key of the error is the path of the value, such as items[1].sku.Required,
+// the rules except Required are skipped if the value is missing or null
+func (v *Validation) ValidSchema(obj interface{}, schema *Schema) (err error) {
+    defer func() {
+        if r := recover(); r != nil {
+            err = fmt.Errorf("%v", r)
+        }
+    }()
+    for _, f := range schema.fields {
+        for _, item := range resolve(obj, f.steps, "") {
+            rules := f.rules
+            if item.value == nil {
+                rules = nil
+                for _, vf := range f.rules {
+                    if vf.Name == "Required" {
+                        rules = append(rules, vf)
+                    }
+                }
+            }
+            // 同 Valid，只需要报第一个错误就可以了，v 可能已经有之前的错误
+            n := len(v.Errors)
+            if !v.applyRules(rules, item.value, item.path, "").Ok {
+                return errors.New(v.Errors[n].Message)
+            }
+        }
+    }
+    return nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "encoding/json"
+    "testing"
+)
+
+func TestNewSchema(t *testing.T) {
+    tests := []struct {
+        rules map[string]string
+        ok    bool
+    }{
+        {map[string]string{"name": "Required;MaxSize(32)", "items[*].sku": "Required", "scores.*": "Range(0,100)"}, true},
+        {map[string]string{"items[0].tags[*]": "Alpha", "matrix[*][*]": "Min(0)"}, true},
+        {map[string]string{"": "Required"}, false},
+        {map[string]string{"items[a]": "Required"}, false},
+        {map[string]string{"items[-1]": "Required"}, false},
+        {map[string]string{"items[0": "Required"}, false},
+        {map[string]string{"a..b": "Required"}, false},
+        {map[string]string{"name": "Nope"}, false},
+        {map[string]string{"name": "MaxSize(a)"}, false},
+        {map[string]string{"status": "Enum(TestSchemaNope)"}, false},
+    }
+    for _, test := range tests {
+        if _, err := NewSchema(test.rules); (err == nil) != test.ok {
+            t.Errorf("NewSchema(%v) error = %v, want ok %v", test.rules, err, test.ok)
+        }
+    }
+}
+
+func TestValidSchema(t *testing.T) {
+    schema, err := NewSchema(map[string]string{
+        "name":         "Required;MaxSize(5)",
+        "age":          "Range(0,150)",
+        "address.city": "Required",
+        "items[*].sku": "Required;AlphaNumeric",
+        "items[*].qty": "Min(1)",
+        "scores.*":     "Range(0,100)",
+        "tags[*]":      "MaxSize(3)",
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+    tests := []struct {
+        payload string
+        key     string
+    }{
+        {`{"name": "beego", "address": {"city": "sh"}, "items": [{"sku": "a1", "qty": 2}], "scores": {"math": 90}, "tags": ["go"]}`, ""},
+        // the missing and null values skip the rules except Required
+        {`{"name": "beego", "age": null, "address": {"city": "sh"}}`, ""},
+        {`{"name": "beego", "address": {"city": "sh"}, "items": [{"sku": "a1"}]}`, ""},
+        // the wildcards of the missing values match nothing
+        {`{"name": "beego", "address": {"city": "sh"}, "items": null, "scores": "x"}`, ""},
+        {`{"address": {"city": "sh"}}`, "name.Required"},
+        {`{"name": "beego"}`, "address.city.Required"},
+        {`{"name": "beego", "address": "sh"}`, "address.city.Required"},
+        {`{"name": "toolong", "address": {"city": "sh"}}`, "name.MaxSize"},
+        {`{"name": "beego", "age": 151, "address": {"city": "sh"}}`, "age.Range"},
+        // the float is not converted to int
+        {`{"name": "beego", "age": 1.5, "address": {"city": "sh"}}`, "age.Range"},
+        {`{"name": "beego", "address": {"city": "sh"}, "items": [{"sku": "a1"}, {"qty": 1}]}`, "items[1].sku.Required"},
+        {`{"name": "beego", "address": {"city": "sh"}, "items": [{"sku": "a-1"}]}`, "items[0].sku.AlphaNumeric"},
+        {`{"name": "beego", "address": {"city": "sh"}, "items": [{"sku": "a1", "qty": 0}]}`, "items[0].qty.Min"},
+        {`{"name": "beego", "address": {"city": "sh"}, "scores": {"math": 90, "art": 101}}`, "scores.art.Range"},
+        {`{"name": "beego", "address": {"city": "sh"}, "tags": ["go", "rust"]}`, "tags[1].MaxSize"},
+    }
+    for _, test := range tests {
+        var payload interface{}
+        if err := json.Unmarshal([]byte(test.payload), &payload); err != nil {
+            t.Fatal(err)
+        }
+        valid := Validation{}
+        err := valid.ValidSchema(payload, schema)
+        key := ""
+        if valid.HasErrors() {
+            key = valid.Errors[0].Key
+            if err == nil || err.Error() != valid.Errors[0].Message {
+                t.Errorf("ValidSchema(%s) error = %v, want %s", test.payload, err, valid.Errors[0].Message)
+            }
+        } else if err != nil {
+            t.Errorf("ValidSchema(%s) error = %v", test.payload, err)
+        }
+        if key != test.key {
+            t.Errorf("ValidSchema(%s) error key = %s, want %s", test.payload, key, test.key)
+        }
+    }
+}
+
+func TestValidSchemaIndex(t *testing.T) {
+    schema, err := NewSchema(map[string]string{"items[1]": "Required;Numeric"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    tests := []struct {
+        payload interface{}
+        key     string
+    }{
+        {map[string]interface{}{"items": []interface{}{"a", "1"}}, ""},
+        {map[string]interface{}{"items": []string{"a", "b"}}, "items[1].Numeric"},
+        {map[string]interface{}{"items": []interface{}{"a"}}, "items[1].Required"},
+        {map[string]interface{}{}, "items[1].Required"},
+    }
+    for i, test := range tests {
+        valid := Validation{}
+        valid.ValidSchema(test.payload, schema)
+        key := ""
+        if valid.HasErrors() {
+            key = valid.Errors[0].Key
+        }
+        if key != test.key {
+            t.Errorf("ValidSchema(%d) error key = %s, want %s", i, key, test.key)
+        }
+    }
+}
@@ -16,11 +16,14 @@ package validation
 
 import (
     "fmt"
+    "net/netip"
     "reflect"
     "regexp"
     "strconv"
     "strings"
     "bytes"
+    "time"
+    "unicode/utf8"
 )
 
 const (
@@ -28,6 +31,10 @@ const (
     ValidTag = "valid"
     // valid里面可以有多个，分号隔开，vdesc里面也可以有多个分号隔开，如果是多个需要一一对应匹配或者vdesc只提供一个，就是一对多也行
     ValidErrDescTag = "vdesc"
+    // SanitizeTag struct tag, 在验证之前对字段做处理，分号隔开，按顺序执行
+    SanitizeTag = "sanitize"
+    // DefaultTag struct tag, 字段为零值时在验证之前设置的默认值
+    DefaultTag = "default"
 )
 
 var (
@@ -37,14 +44,15 @@ var (
 
     // doesn't belong to validation functions
     unFuncs = map[string]bool{
-        "Clear":     true,
-        "HasErrors": true,
-        "ErrorMap":  true,
-        "Error":     true,
-        "apply":     true,
-        "Check":     true,
-        "Valid":     true,
-        "NoMatch":   true,
+        "Clear":       true,
+        "HasErrors":   true,
+        "ErrorMap":    true,
+        "Error":       true,
+        "apply":       true,
+        "Check":       true,
+        "Valid":       true,
+        "ValidSchema": true,
+        "NoMatch":     true,
     }
 )
 
@@ -57,6 +65,31 @@ func init() {
             funcs[m.Name] = m.Func
         }
     }
+    for name, f := range tagVariants {
+        funcs[variantName(name)] = reflect.ValueOf(f)
+    }
+}
+
+// key: the name of the valid function in the tag
+// value: the tag only variant of the valid function, it has more parameters than the method of Validation,
+// and is used if the parameters in the tag don't fit the method, such as Email(name,max=100)
+var tagVariants = map[string]interface{}{
+    "Base64":  base64WithOptions,
+    "Email":   emailWithOptions,
+    "Length":  lengthMode,
+    "MaxSize": maxSizeMode,
+    "MinSize": minSizeMode,
+    "Phone":   phoneInRegions,
+}
+
+// variantName return the name of the tag only variant in funcs, it can not be used in the tag directly
+func variantName(name string) string {
+    return name + "()"
+}
+
+// ruleName return the name of the valid function in the tag, the variant has the same name as the method
+func ruleName(name string) string {
+    return strings.TrimSuffix(name, "()")
 }
 
 // CustomFunc is for custom validate function
@@ -70,6 +103,7 @@ type CustomFunc func(v *Validation, obj interface{}, key string)
 //   Error
 //   Check
 //   Valid
+//   ValidSchema
 //   NoMatch
 // If the name is same with exists function, it will replace the origin valid function
 func AddCustomFunc(name string, f CustomFunc) error {
@@ -78,6 +112,7 @@ func AddCustomFunc(name string, f CustomFunc) error {
     }
 
     funcs[name] = reflect.ValueOf(f)
+    resetPlans()
     return nil
 }
 
@@ -88,6 +123,44 @@ type ValidFunc struct {
     Params []interface{}
 }
 
+// Rules the nested rules in the tag, such as the AlphaDash and MaxSize(10) of Keys(AlphaDash,MaxSize(10)),
+// they are parsed once with the struct tags, the key of the params is set when they are applied
+type Rules []ValidFunc
+
+// parseRules parse the nested rules, the Match can be Match(/.../) as in the tag
+func parseRules(s []string) (rules Rules, err error) {
+    expanded, err := expandAliases(s, "", nil)
+    if err != nil {
+        return
+    }
+    for _, r := range expanded {
+        if len(r.rule) == 0 {
+            continue
+        }
+        var vf ValidFunc
+        if vf, err = parseRule(r.rule, r.errDesc, ""); err != nil {
+            return
+        }
+        rules = append(rules, vf)
+    }
+    if len(rules) == 0 {
+        err = fmt.Errorf("the nested rules can not be empty")
+    }
+    return
+}
+
+// parseRule parse a rule which can be Match(/.../)
+func parseRule(rule, errDesc, key string) (ValidFunc, error) {
+    if strings.HasPrefix(rule, "Match(/") && strings.HasSuffix(rule, "/)") {
+        reg, err := regexp.Compile(rule[len("Match(/") : len(rule) - len("/)")])
+        if err != nil {
+            return ValidFunc{}, err
+        }
+        return ValidFunc{"Match", errDesc, []interface{}{reg, key + ".Match"}}, nil
+    }
+    return parseFunc(rule, errDesc, key)
+}
+
 // Funcs Validate function map
 type Funcs map[string]reflect.Value
 
@@ -109,6 +182,10 @@ func (f Funcs) Call(name string, params ...interface{}) (result []reflect.Value,
     in := make([]reflect.Value, len(params))
     for k, param := range params {
         in[k] = reflect.ValueOf(param)
+        // the nil obj, such as a missing value of the Schema
+        if param == nil {
+            in[k] = reflect.Zero(f[name].Type().In(k))
+        }
     }
     result = f[name].Call(in)
     return
@@ -124,15 +201,20 @@ func isStructPtr(t reflect.Type) bool {
 
 // 增加对错误描述tag的处理
 func getValidFuncs(f reflect.StructField) (vfs []ValidFunc, err error) {
-    tag := strings.TrimSpace(f.Tag.Get(ValidTag))
+    return parseValidTag(f.Tag.Get(ValidTag), f.Tag.Get(ValidErrDescTag), f.Name)
+}
+
+// parseValidTag parse the rules of the valid tag and the messages of the vdesc tag, name is the field name
+func parseValidTag(tag, errDescTag, name string) (vfs []ValidFunc, err error) {
+    tag = strings.TrimSpace(tag)
     if len(tag) == 0 {
         return
     }
-    errDescTag := strings.TrimSpace(f.Tag.Get(ValidErrDescTag))
-    if vfs, tag, errDescTag, err = getRegFuncs(tag, errDescTag, f.Name); err != nil {
+    errDescTag = strings.TrimSpace(errDescTag)
+    if vfs, tag, errDescTag, err = getRegFuncs(tag, errDescTag, name); err != nil {
         return
     }
-    fs := strings.Split(tag, ";")
+    fs := splitTop(tag, ';')
     var fsDesc []string
     isFsArray := false
     if strings.Index(errDescTag, ";") >= 0 {
@@ -153,12 +235,23 @@ func getValidFuncs(f reflect.StructField) (vfs []ValidFunc, err error) {
             }
         }
 
-        vf, err = parseFunc(vfunc, errDesc, f.Name)
-        if err != nil {
+        // the aliases are expanded to their rules, such as Username
+        var expanded []aliasRule
+        if expanded, err = expandAliases([]string{vfunc}, "", nil); err != nil {
             return
         }
+        for _, r := range expanded {
+            desc := errDesc
+            if strings.TrimSpace(desc) == "" {
+                desc = r.errDesc
+            }
+            vf, err = parseRule(r.rule, desc, name)
+            if err != nil {
+                return
+            }
 
-        vfs = append(vfs, vf)
+            vfs = append(vfs, vf)
+        }
     }
     return
 }
@@ -167,7 +260,8 @@ func getValidFuncs(f reflect.StructField) (vfs []ValidFunc, err error) {
 // May be get NoMatch function in the future
 func getRegFuncs(tag, errDescTag, key string) (vfs []ValidFunc, newTag string, newErrDescTag string, err error) {
     index := strings.Index(tag, "Match(/")
-    if index == -1 {
+    // the Match in the nested rules is parsed by parseRules, such as Values(Match(/^a/))
+    if index == -1 || strings.Count(tag[:index], "(") != strings.Count(tag[:index], ")") {
         newTag = tag
         newErrDescTag = errDescTag
         return
@@ -226,6 +320,14 @@ func parseFunc(vfunc, errDesc, key string) (v ValidFunc, err error) {
         if num, err = numIn(vfunc); err != nil {
             return
         }
+        if num == 1 && listIn(vfunc) {
+            var tParams []interface{}
+            if tParams, err = trim(vfunc, key + "." + vfunc, nil); err != nil {
+                return
+            }
+            v = ValidFunc{vfunc, errDesc, tParams}
+            return
+        }
         if num != 0 {
             err = fmt.Errorf("%s require %d parameters", vfunc, num)
             return
@@ -234,8 +336,9 @@ func parseFunc(vfunc, errDesc, key string) (v ValidFunc, err error) {
         return
     }
 
-    end := strings.Index(vfunc, ")")
-    if end == -1 {
+    // the parameters can be nested rules, such as Values(Range(0,100))
+    end := strings.LastIndex(vfunc, ")")
+    if end != len(vfunc) - 1 {
         err = fmt.Errorf("invalid valid function")
         return
     }
@@ -245,21 +348,48 @@ func parseFunc(vfunc, errDesc, key string) (v ValidFunc, err error) {
         return
     }
 
-    params := strings.Split(vfunc[start + 1:end], ",")
-    // the num of param must be equal
-    if num != len(params) {
+    params := splitTop(vfunc[start + 1:end], ',')
+    // the expression is a single parameter, which can contain commas, such as Expr(Type != 'a,b')
+    if num == 1 && funcs[name].Type().In(2) == expressionType {
+        params = []string{vfunc[start + 1:end]}
+    }
+    // the tag only variant, such as Email(name) of Email
+    fname := name
+    if _, ok := tagVariants[name]; ok && !fitIn(name, len(params)) && fitIn(variantName(name), len(params)) {
+        fname = variantName(name)
+        num, _ = numIn(fname)
+    }
+    // the num of param must be equal, except the last []string param which collect all the rest params
+    if listIn(fname) {
+        if len(params) < num - 1 {
+            err = fmt.Errorf("%s require at least %d parameters", name, num - 1)
+            return
+        }
+    } else if num != len(params) {
         err = fmt.Errorf("%s require %d parameters", name, num)
         return
     }
 
-    tParams, err := trim(name, key + "." + name, params)
+    tParams, err := trim(fname, key + "." + name, params)
     if err != nil {
         return
     }
-    v = ValidFunc{name, errDesc, tParams}
+    v = ValidFunc{fname, errDesc, tParams}
     return
 }
 
+// fitIn whether n parameters in the tag fit the valid function
+func fitIn(name string, n int) bool {
+    num, err := numIn(name)
+    if err != nil {
+        return false
+    }
+    if listIn(name) {
+        return n >= num - 1
+    }
+    return n == num
+}
+
 func numIn(name string) (num int, err error) {
     fn, ok := funcs[name]
     if !ok {
@@ -271,13 +401,162 @@ func numIn(name string) (num int, err error) {
     return
 }
 
+// listIn whether the last parameter (before key and errDesc) of the valid function is []string or Rules,
+// which collect all the rest parameters in the tag, such as In(a,b,c) or Keys(AlphaDash,MaxSize(10))
+func listIn(name string) bool {
+    fn, ok := funcs[name]
+    if !ok {
+        return false
+    }
+    n := fn.Type().NumIn()
+    if n < 5 {
+        return false
+    }
+    t := fn.Type().In(n - 3)
+    return t == stringsType || t == rulesType || listParsers[t] != nil
+}
+
+var (
+    stringsType = reflect.TypeOf([]string{})
+    rulesType   = reflect.TypeOf(Rules{})
+)
+
+// key: the type of the last parameter of the valid function, which collect all the rest parameters in the tag
+// value: parse the parameters once when the tag is parsed, so the invalid options are reported by Valid
+// before any value is validated, such as Script(Han,Latin)
+var listParsers = map[reflect.Type]func(list []string) (interface{}, error){
+    reflect.TypeOf(UUIDVersions{}): func(list []string) (interface{}, error) {
+        return ParseUUIDVersions(list...)
+    },
+    reflect.TypeOf(CardBrands{}): func(list []string) (interface{}, error) {
+        return ParseCardBrands(list...)
+    },
+    reflect.TypeOf(URLOptions{}): func(list []string) (interface{}, error) {
+        return ParseURLOptions(list...)
+    },
+    reflect.TypeOf(UniqueField("")): func(list []string) (interface{}, error) {
+        return ParseUniqueField(list...)
+    },
+    reflect.TypeOf(PasswordOptions{}): func(list []string) (interface{}, error) {
+        return ParsePasswordOptions(list...)
+    },
+    reflect.TypeOf(Base64Options{}): func(list []string) (interface{}, error) {
+        return ParseBase64Options(list...)
+    },
+    reflect.TypeOf(EmailOptions{}): func(list []string) (interface{}, error) {
+        return ParseEmailOptions(list...)
+    },
+    reflect.TypeOf(PhoneRegions{}): func(list []string) (interface{}, error) {
+        return ParsePhoneRegions(list...)
+    },
+    reflect.TypeOf(Scripts{}): func(list []string) (interface{}, error) {
+        return ParseScripts(list...)
+    },
+}
+
+// splitTop split s by sep which is not in the parentheses or the regexp of Match(/.../),
+// such as "Required;Values(Range(0,100))" is split to "Required" and "Values(Range(0,100))"
+func splitTop(s string, sep byte) (parts []string) {
+    depth, start := 0, 0
+    for i := 0; i < len(s); i++ {
+        switch s[i] {
+        case '(':
+            if strings.HasPrefix(s[i:], "(/") && strings.HasSuffix(s[:i], "Match") {
+                if end := strings.Index(s[i:], "/)"); end > 0 {
+                    i += end + 1
+                    continue
+                }
+            }
+            // the strings of the expression can contain the parentheses and separators, such as Expr(Type != 'a;b')
+            if strings.HasSuffix(s[:i], "Expr") {
+                if end := exprEnd(s[i:]); end > 0 {
+                    i += end
+                    continue
+                }
+            }
+            depth++
+        case ')':
+            if depth > 0 {
+                depth--
+            }
+        case sep:
+            if depth == 0 {
+                parts = append(parts, s[start:i])
+                start = i + 1
+            }
+        }
+    }
+    return append(parts, s[start:])
+}
+
+// exprEnd return the index of the ) which closes the ( at the beginning of s,
+// the parentheses in the quoted strings are skipped, it returns -1 if there is no such )
+func exprEnd(s string) int {
+    depth := 0
+    var quote byte
+    for i := 0; i < len(s); i++ {
+        switch c := s[i]; {
+        case quote != 0:
+            if c == quote {
+                quote = 0
+            }
+        case c == '\'' || c == '"':
+            quote = c
+        case c == '(':
+            depth++
+        case c == ')':
+            if depth--; depth == 0 {
+                return i
+            }
+        }
+    }
+    return -1
+}
+
 func trim(name, key string, s []string) (ts []interface{}, err error) {
-    ts = make([]interface{}, len(s), len(s) + 1)
     fn, ok := funcs[name]
     if !ok {
         err = fmt.Errorf("doesn't exsits %s valid function", name)
         return
     }
+    if listIn(name) {
+        // skip *Validation and obj, key and errDesc params
+        n := fn.Type().NumIn() - 5
+        if ts, err = trimParams(fn, s[:n]); err != nil {
+            return
+        }
+        if fn.Type().In(n + 2) == rulesType {
+            var rules Rules
+            if rules, err = parseRules(s[n:]); err != nil {
+                return
+            }
+            ts = append(ts, rules, key)
+            return
+        }
+        var list []string
+        for _, p := range s[n:] {
+            list = append(list, strings.TrimSpace(p))
+        }
+        if parse, ok := listParsers[fn.Type().In(n + 2)]; ok {
+            var param interface{}
+            if param, err = parse(list); err != nil {
+                return
+            }
+            ts = append(ts, param, key)
+            return
+        }
+        ts = append(ts, list, key)
+        return
+    }
+    if ts, err = trimParams(fn, s); err != nil {
+        return
+    }
+    ts = append(ts, key)
+    return
+}
+
+func trimParams(fn reflect.Value, s []string) (ts []interface{}, err error) {
+    ts = make([]interface{}, len(s), len(s) + 2)
     for i := 0; i < len(s); i++ {
         var param interface{}
         // skip *Validation and obj params
@@ -286,29 +565,92 @@ func trim(name, key string, s []string) (ts []interface{}, err error) {
         }
         ts[i] = param
     }
-    ts = append(ts, key)
     return
 }
 
-// modify the parameters's type to adapt the function input parameters' type
+// modify the parameters's type to adapt the function input parameters' type,
+// it also convert the values of the default tag and the In values to the type of the field,
+// support string, bool, all numeric kinds, time.Duration, time.Time, Char and the parameter types of the rules
 func parseParam(t reflect.Type, s string) (i interface{}, err error) {
+    switch t {
+    case durationType:
+        return time.ParseDuration(s)
+    case timeType:
+        for _, layout := range timeLayouts {
+            if i, err = time.ParseInLocation(layout, s, time.Local); err == nil {
+                return
+            }
+        }
+        return nil, fmt.Errorf("invalid time %s", s)
+    case charType:
+        if utf8.RuneCountInString(s) != 1 {
+            return nil, fmt.Errorf("%s is not a single character", s)
+        }
+        r, _ := utf8.DecodeRuneInString(s)
+        return Char(r), nil
+    case prefixType:
+        return netip.ParsePrefix(s)
+    case timeExprType:
+        return ParseTimeExpr(s)
+    case expressionType:
+        return ParseExpression(s)
+    case sizeModeType:
+        return ParseSizeMode(s)
+    }
+
+    v := reflect.New(t).Elem()
     switch t.Kind() {
-    case reflect.Int:
-        i, err = strconv.Atoi(s)
     case reflect.String:
-        i = s
+        v.SetString(s)
+    case reflect.Bool:
+        var b bool
+        if b, err = strconv.ParseBool(s); err == nil {
+            v.SetBool(b)
+        }
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+        var n int64
+        if n, err = strconv.ParseInt(s, 10, t.Bits()); err == nil {
+            v.SetInt(n)
+        }
+    case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+        var u uint64
+        if u, err = strconv.ParseUint(s, 10, t.Bits()); err == nil {
+            v.SetUint(u)
+        }
+    case reflect.Float32, reflect.Float64:
+        var f float64
+        if f, err = strconv.ParseFloat(s, t.Bits()); err == nil {
+            v.SetFloat(f)
+        }
     case reflect.Ptr:
         if t.Elem().String() != "regexp.Regexp" {
-            err = fmt.Errorf("does not support %s", t.Elem().String())
-            return
+            return nil, fmt.Errorf("does not support %s", t.Elem().String())
         }
-        i, err = regexp.Compile(s)
+        return regexp.Compile(s)
+    case reflect.Struct:
+        return nil, fmt.Errorf("does not support %s", t.String())
     default:
-        err = fmt.Errorf("does not support %s", t.Kind().String())
+        return nil, fmt.Errorf("does not support %s", t.Kind().String())
     }
-    return
+    if err != nil {
+        return nil, err
+    }
+    return v.Interface(), nil
 }
 
+var (
+    durationType   = reflect.TypeOf(time.Duration(0))
+    timeType       = reflect.TypeOf(time.Time{})
+    charType       = reflect.TypeOf(Char(0))
+    prefixType     = reflect.TypeOf(netip.Prefix{})
+    timeExprType   = reflect.TypeOf(TimeExpr{})
+    expressionType = reflect.TypeOf(Expression{})
+    sizeModeType   = reflect.TypeOf(SizeMode(""))
+)
+
+// time layouts supported by the default tag
+var timeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"}
+
 func mergeParam(v *Validation, obj interface{}, errDesc string, params []interface{}) []interface{} {
     return append(append([]interface{}{v, obj}, params...), errDesc)
 }
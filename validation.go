@@ -32,6 +32,9 @@ type Error struct {
     Message, Key, Name, Field, Tmpl string
     Value                           interface{}
     LimitValue                      interface{}
+    // the reason why the value is invalid, only set by the validators which implement Reasoner,
+    // such as PasswordPolicy, so the UI can show exactly what's wrong
+    Reason string
 }
 
 // String Returns the Message.
@@ -79,6 +82,21 @@ func (r *Result) Message(message string, args ...interface{}) *Result {
 type Validation struct {
     Errors    []*Error
     ErrorsMap map[string]*Error
+
+    // the struct being validated by Valid, for the cross field references
+    current reflect.Value
+}
+
+// fieldValue return the value of the field of the struct being validated by Valid
+func (v *Validation) fieldValue(name string) interface{} {
+    if !v.current.IsValid() {
+        panic(fmt.Errorf("the field %s can only be referenced in Valid", name))
+    }
+    fv := v.current.FieldByName(name)
+    if !fv.IsValid() || !fv.CanInterface() {
+        panic(fmt.Errorf("doesn't exist %s field", name))
+    }
+    return fv.Interface()
 }
 
 // Clear Clean all ValidationError.
@@ -129,21 +147,36 @@ func (v *Validation) Range(obj interface{}, min, max int, key string, errDesc st
     return v.apply(Range{Min{Min: min}, Max{Max: max}, key}, obj, errDesc)
 }
 
-// MinSize Test that the obj is longer than min size if type is string or slice
+// MinSize Test that the obj is longer than min size if type is string, slice, array, map or channel
 func (v *Validation) MinSize(obj interface{}, min int, key string, errDesc string) *Result {
     return v.apply(MinSize{min, key}, obj, errDesc)
 }
 
-// MaxSize Test that the obj is shorter than max size if type is string or slice
+// MaxSize Test that the obj is shorter than max size if type is string, slice, array, map or channel
 func (v *Validation) MaxSize(obj interface{}, max int, key string, errDesc string) *Result {
     return v.apply(MaxSize{max, key}, obj, errDesc)
 }
 
-// Length Test that the obj is same length to n if type is string or slice
+// Length Test that the obj is same length to n if type is string, slice, array, map or channel
 func (v *Validation) Length(obj interface{}, n int, key string, errDesc string) *Result {
     return v.apply(Length{n, key}, obj, errDesc)
 }
 
+// minSizeMode the tag only variant of MinSize, the size of the string is counted by the mode, such as MinSize(2,width)
+func minSizeMode(v *Validation, obj interface{}, min int, mode SizeMode, key string, errDesc string) *Result {
+    return v.apply(MinSizeMode{min, mode, key}, obj, errDesc)
+}
+
+// maxSizeMode the tag only variant of MaxSize, the size of the string is counted by the mode, such as MaxSize(255,bytes)
+func maxSizeMode(v *Validation, obj interface{}, max int, mode SizeMode, key string, errDesc string) *Result {
+    return v.apply(MaxSizeMode{max, mode, key}, obj, errDesc)
+}
+
+// lengthMode the tag only variant of Length, the size of the string is counted by the mode, such as Length(6,bytes)
+func lengthMode(v *Validation, obj interface{}, n int, mode SizeMode, key string, errDesc string) *Result {
+    return v.apply(LengthMode{n, mode, key}, obj, errDesc)
+}
+
 // Alpha Test that the obj is [a-zA-Z] if type is string
 func (v *Validation) Alpha(obj interface{}, key string, errDesc string) *Result {
     return v.apply(Alpha{key}, obj, errDesc)
@@ -174,24 +207,19 @@ func (v *Validation) AlphaDash(obj interface{}, key string, errDesc string) *Res
     return v.apply(AlphaDash{NoMatch{Match: Match{Regexp: alphaDashPattern}}, key}, obj, errDesc)
 }
 
-// Email Test that the obj is email address if type is string
-func (v *Validation) Email(obj interface{}, key string, errDesc string) *Result {
-    return v.apply(Email{Match{Regexp: emailPattern}, key}, obj, errDesc)
-}
-
-// IP Test that the obj is IP address if type is string
-func (v *Validation) IP(obj interface{}, key string, errDesc string) *Result {
-    return v.apply(IP{Match{Regexp: ipPattern}, key}, obj, errDesc)
-}
-
 // Base64 Test that the obj is base64 encoded if type is string
 func (v *Validation) Base64(obj interface{}, key string, errDesc string) *Result {
-    return v.apply(Base64{Match{Regexp: base64Pattern}, key}, obj, errDesc)
+    return v.apply(Base64{Key: key}, obj, errDesc)
+}
+
+// base64WithOptions the tag only variant of Base64, such as Base64(url,maxBytes=1024), see Base64Options
+func base64WithOptions(v *Validation, obj interface{}, options Base64Options, key string, errDesc string) *Result {
+    return v.apply(Base64WithOptions{options, key}, obj, errDesc)
 }
 
 // Mobile Test that the obj is chinese mobile number if type is string
 func (v *Validation) Mobile(obj interface{}, key string, errDesc string) *Result {
-    return v.apply(Mobile{Match{Regexp: mobilePattern}, key}, obj, errDesc)
+    return v.apply(Mobile{Key: key}, obj, errDesc)
 }
 
 // Tel Test that the obj is chinese telephone number if type is string
@@ -201,8 +229,13 @@ func (v *Validation) Tel(obj interface{}, key string, errDesc string) *Result {
 
 // Phone Test that the obj is chinese mobile or telephone number if type is string
 func (v *Validation) Phone(obj interface{}, key string, errDesc string) *Result {
-    return v.apply(Phone{Mobile{Match: Match{Regexp: mobilePattern}},
-        Tel{Match: Match{Regexp: telPattern}}, key}, obj, errDesc)
+    return v.apply(Phone{Mobile{}, Tel{Match: Match{Regexp: telPattern}}, key}, obj, errDesc)
+}
+
+// phoneInRegions the tag only variant of Phone, the obj is the phone number of one of the regions,
+// such as Phone(US,CA)
+func phoneInRegions(v *Validation, obj interface{}, regions PhoneRegions, key string, errDesc string) *Result {
+    return v.apply(PhoneInRegions{regions, key}, obj, errDesc)
 }
 
 // ZipCode Test that the obj is chinese zip code if type is string
@@ -220,17 +253,20 @@ func (v *Validation) apply(chk Validator, obj interface{}, errDesc string) *Resu
     Name := key
     Field := ""
 
-    parts := strings.Split(key, ".")
-    if len(parts) == 2 {
-        Field = parts[0]
-        Name = parts[1]
+    // the field can be an indexed path with dots, such as Tags[a.b]
+    if index := strings.LastIndex(key, "."); index >= 0 {
+        Field = key[:index]
+        Name = key[index + 1:]
     }
 
-    errMsg := ""
-    if strings.TrimSpace(errDesc) == "" {
-        errMsg = chk.DefaultMessage()
-    } else {
+    errMsg, reason := "", ""
+    if r, ok := chk.(Reasoner); ok {
+        reason, errMsg = r.Reason(obj)
+    }
+    if strings.TrimSpace(errDesc) != "" {
         errMsg = errDesc
+    } else if errMsg == "" {
+        errMsg = chk.DefaultMessage()
     }
 
     err := &Error{
@@ -241,6 +277,7 @@ func (v *Validation) apply(chk Validator, obj interface{}, errDesc string) *Resu
         Value:      obj,
         Tmpl:       MessageTmpls[Name],
         LimitValue: chk.GetLimitValue(),
+        Reason:     reason,
     }
     v.setError(err)
 
@@ -251,6 +288,17 @@ func (v *Validation) apply(chk Validator, obj interface{}, errDesc string) *Resu
     }
 }
 
+// ruleError record the error of a rule which can not be applied, such as the field referenced by the rule
+// doesn't exist, so the validation fails with the error instead of panic
+func (v *Validation) ruleError(key string, err error) *Result {
+    e := &Error{Message: err.Error(), Key: key, Name: key}
+    if index := strings.LastIndex(key, "."); index >= 0 {
+        e.Field, e.Name = key[:index], key[index + 1:]
+    }
+    v.setError(e)
+    return &Result{Error: e}
+}
+
 func (v *Validation) setError(err *Error) {
     v.Errors = append(v.Errors, err)
     if v.ErrorsMap == nil {
@@ -284,6 +332,8 @@ func (v *Validation) SetError(fieldName string, errMsg string) *Error {
 
 // Valid Validate a struct.
 // the obj parameter must be a struct or a struct pointer
+// the default and sanitize tags are applied before validation, the obj must be a struct pointer if they are used
+// the tags of a struct type are compiled only once, and cached
 // 因为前台已经处理了一轮了，所以这里只需要处理到一个错误，就可以退出了, 增加一个错误描述tag
 func (v *Validation) Valid(obj interface{}) (err error) {
     objT := reflect.TypeOf(obj)
@@ -298,14 +348,22 @@ func (v *Validation) Valid(obj interface{}) (err error) {
         return
     }
 
-    for i := 0; i < objT.NumField(); i++ {
-        var vfs []ValidFunc
-        if vfs, err = getValidFuncs(objT.Field(i)); err != nil {
-            return
-        }
-        for _, vf := range vfs {
+    var p *structPlan
+    if p, err = getPlan(objT); err != nil {
+        return
+    }
+    if err = p.prepare(objV); err != nil {
+        return
+    }
+    v.current = objV
+    defer func() {
+        v.current = reflect.Value{}
+    }()
+
+    for _, fp := range p.fields {
+        for _, vf := range fp.vfs {
             if _, err = funcs.Call(vf.Name,
-                mergeParam(v, objV.Field(i).Interface(), vf.ErrMsg, vf.Params)...); err != nil {
+                mergeParam(v, objV.Field(fp.index).Interface(), vf.ErrMsg, vf.Params)...); err != nil {
                 return
             }
             // 因为前端已经做了验证了，所以这边只需要报第一个错误就可以了
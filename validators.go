@@ -15,41 +15,164 @@
 package validation
 
 import (
+    "encoding/base64"
     "fmt"
+    "strconv"
     "reflect"
     "regexp"
     "time"
     "unicode/utf8"
     "strings"
+
+    "github.com/rivo/uniseg"
 )
 
 // MessageTmpls store commond validate template
 var MessageTmpls = map[string]string{
-    "Required":     "%s 不能为空",
-    "Min":          "%s 不能小于 %d",
-    "Max":          "%s 不能大于 %d",
-    "Range":        "%s 超过有效取值区间 %d 到 %d",
-    "MinSize":      "%s 不能短于 %d 个字符",
-    "MaxSize":      "%s 不能长于 %d 个字符",
-    "Length":       "%s 必须为 %d 个字符",
-    "Alpha":        "%s 无效的字母",
-    "Numeric":      "%s 无效的数字",
-    "AlphaNumeric": "%s 无效的字母或数字",
-    "Match":        "%s 必须匹配格式 %s",
-    "NoMatch":      "%s 必须不匹配格式 %s",
-    "AlphaDash":    "%s 必须是字母或数字或-或_",
-    "Email":        "%s 无效的email",
-    "IP":           "%s 无效的ip地址",
-    "Base64":       "%s 无效的base64格式",
-    "Mobile":       "%s 无效的手机号",
-    "Tel":          "%s 无效的电话号码",
-    "Phone":        "%s 无效的手机号或电话号码",
-    "ZipCode":      "%s 无效的邮政编码",
+    "Required":            "%s 不能为空",
+    "Min":                 "%s 不能小于 %d",
+    "Max":                 "%s 不能大于 %d",
+    "Range":               "%s 超过有效取值区间 %d 到 %d",
+    "MinSize":             "%s 不能短于 %d 个字符",
+    "MaxSize":             "%s 不能长于 %d 个字符",
+    "Length":              "%s 必须为 %d 个字符",
+    "Alpha":               "%s 无效的字母",
+    "Numeric":             "%s 无效的数字",
+    "AlphaNumeric":        "%s 无效的字母或数字",
+    "Match":               "%s 必须匹配格式 %s",
+    "NoMatch":             "%s 必须不匹配格式 %s",
+    "AlphaDash":           "%s 必须是字母或数字或-或_",
+    "Email":               "%s 无效的email",
+    "IP":                  "%s 无效的ip地址",
+    "Base64":              "%s 无效的base64格式",
+    "Mobile":              "%s 无效的手机号",
+    "Tel":                 "%s 无效的电话号码",
+    "Phone":               "%s 无效的手机号或电话号码",
+    "ZipCode":             "%s 无效的邮政编码",
+    "In":                  "%s 必须是 %s 中的一个",
+    "OneOf":               "%s 必须是 %s 中的一个",
+    "InFold":              "%s 必须是 %s 中的一个",
+    "NotIn":               "%s 不能是 %s 中的任何一个",
+    "NotInFold":           "%s 不能是 %s 中的任何一个",
+    "Enum":                "%s 必须是 %s 中的一个",
+    "Contains":            "%s 必须包含 %s",
+    "ContainsAny":         "%s 必须包含 %s 中的任意一个字符",
+    "Excludes":            "%s 不能包含 %s",
+    "ExcludesRune":        "%s 不能包含字符 %s",
+    "StartsWith":          "%s 必须以 %s 开头",
+    "EndsWith":            "%s 必须以 %s 结尾",
+    "ASCII":               "%s 只能包含ascii字符",
+    "PrintableASCII":      "%s 只能包含可打印的ascii字符",
+    "Lowercase":           "%s 不能包含大写字母",
+    "Uppercase":           "%s 不能包含小写字母",
+    "NoWhitespace":        "%s 不能包含空白字符",
+    "UnicodeAlpha":        "%s 只能包含文字",
+    "UnicodeAlphaNumeric": "%s 只能包含文字或数字",
+    "Han":                 "%s 只能包含汉字",
+    "ChineseName":         "%s 无效的中文姓名",
+    "Script":              "%s 只能包含 %s 文字",
+    "IPv4":                "%s 无效的ipv4地址",
+    "IPv6":                "%s 无效的ipv6地址",
+    "CIDR":                "%s 无效的CIDR网段",
+    "CIDRv4":              "%s 无效的ipv4 CIDR网段",
+    "CIDRv6":              "%s 无效的ipv6 CIDR网段",
+    "IPInSubnet":          "%s 必须是网段 %s 中的ip地址",
+    "PrivateIP":           "%s 必须是内网ip地址",
+    "PublicIP":            "%s 必须是公网ip地址",
+    "TCPAddr":             "%s 无效的地址，格式为 主机:端口",
+    "Port":                "%s 无效的端口号",
+    "MAC":                 "%s 无效的MAC地址",
+    "URL":                 "%s 无效的url地址",
+    "URLScheme":           "%s 无效的url地址，协议必须是 %s 中的一个",
+    "URLHost":             "%s 无效的url地址，域名必须是 %s 中的一个",
+    "URLSchemeHost":       "%s 无效的url地址，协议必须是 %s 中的一个，域名必须是 %s 中的一个",
+    "URI":                 "%s 无效的uri",
+    "RelativeURL":         "%s 无效的相对url地址",
+    "Hostname":            "%s 无效的主机名",
+    "FQDN":                "%s 无效的完整域名",
+    "DomainWithTLD":       "%s 无效的域名",
+    "DataURI":             "%s 无效的data uri",
+    "IDCard":              "%s 无效的身份证号码",
+    "USCC":                "%s 无效的统一社会信用代码",
+    "OrgCode":             "%s 无效的组织机构代码",
+    "BankCard":            "%s 无效的银行卡号",
+    "LicensePlate":        "%s 无效的车牌号",
+    "E164":                "%s 无效的E.164格式电话号码",
+    "PhoneRegion":         "%s 无效的 %s 电话号码",
+    "CreditCard":          "%s 无效的信用卡号",
+    "CreditCardBrand":     "%s 无效的信用卡号，卡组织必须是 %s 中的一个",
+    "IBAN":                "%s 无效的IBAN账号",
+    "BIC":                 "%s 无效的BIC/SWIFT代码",
+    "Currency":            "%s 无效的货币代码",
+    "Money":               "%s 无效的金额，最多 %d 位小数",
+    "DateFormat":          "%s 必须是 %s 格式的时间",
+    "Before":              "%s 必须早于 %s",
+    "After":               "%s 必须晚于 %s",
+    "Between":             "%s 必须在 %s 到 %s 之间",
+    "MinAge":              "%s 年龄不能小于 %d 岁",
+    "MaxAge":              "%s 年龄不能大于 %d 岁",
+    "UUID":                "%s 无效的UUID",
+    "UUIDVersion":         "%s 必须是版本为 %s 的UUID",
+    "ULID":                "%s 无效的ULID",
+    "Semver":              "%s 无效的语义化版本号",
+    "Hexadecimal":         "%s 无效的十六进制数",
+    "HexColor":            "%s 无效的十六进制颜色",
+    "RGB":                 "%s 无效的rgb颜色",
+    "RGBA":                "%s 无效的rgba颜色",
+    "ISBN10":              "%s 无效的10位ISBN",
+    "ISBN13":              "%s 无效的13位ISBN",
+    "ObjectID":            "%s 无效的ObjectID",
+    "Base64MinBytes":      "%s 无效的base64格式或解码后少于 %d 字节",
+    "Base64MaxBytes":      "%s 无效的base64格式或解码后超过 %d 字节",
+    "Base64Range":         "%s 无效的base64格式或解码后不在 %d 到 %d 字节之间",
+    "IsJSON":              "%s 无效的JSON格式",
+    "JSONObject":          "%s 必须是JSON对象",
+    "IsXML":               "%s 无效的XML格式",
+    "CSVRow":              "%s 必须是一行CSV记录",
+    "CSVRowColumns":       "%s 必须是一行 %d 列的CSV记录",
+    "IsRegexp":            "%s 无效的正则表达式",
+    "JSONSchemaLite":      "%s 不符合JSON Schema %s",
+    "Latitude":            "%s 必须是 -90 到 90 之间的纬度",
+    "Longitude":           "%s 必须是 -180 到 180 之间的经度",
+    "LatLng":              "%s 必须是 \"纬度,经度\" 格式的坐标",
+    "Timezone":            "%s 无效的时区名称",
+    "CountryAlpha2":       "%s 无效的两位国家代码",
+    "CountryAlpha3":       "%s 无效的三位国家代码",
+    "Language":            "%s 无效的语言代码",
+    "RegionCodeFormat":    "%s 行政区划代码格式不正确",
+    "PasswordPolicy":      "%s 不符合密码规则",
+    "PasswordMinLength":   "%s 长度不能小于 %d",
+    "PasswordLower":       "%s 必须包含小写字母",
+    "PasswordUpper":       "%s 必须包含大写字母",
+    "PasswordDigit":       "%s 必须包含数字",
+    "PasswordSymbol":      "%s 必须包含符号",
+    "PasswordClasses":     "%s 必须包含大写字母、小写字母、数字和符号中的至少 %d 种",
+    "PasswordRepeat":      "%s 不能包含超过 %d 个连续相同的字符",
+    "PasswordSequence":    "%s 不能包含 %d 位或更长的连续字符，例如 1234",
+    "PasswordUsername":    "%s 不能包含用户名",
+    "MinSizeBytes":        "%s 不能短于 %d 个字节",
+    "MaxSizeBytes":        "%s 不能长于 %d 个字节",
+    "LengthBytes":         "%s 必须为 %d 个字节",
+    "MinSizeWidth":        "%s 显示宽度不能小于 %d",
+    "MaxSizeWidth":        "%s 显示宽度不能大于 %d",
+    "LengthWidth":         "%s 显示宽度必须为 %d",
+    "Unique":              "%s 不能包含重复的元素",
+    "UniqueField":         "%s 中元素的 %s 不能重复",
+    "ContainsItem":        "%s 必须包含 %s",
+    "SubsetOf":            "%s 的元素必须是 %s 中的一个",
+    "MinItems":            "%s 至少包含 %d 个元素",
+    "MaxItems":            "%s 最多包含 %d 个元素",
+    "Keys":                "%s 必须是map",
+    "Values":              "%s 必须是map、切片或数组",
+    "Or":                  "%s 不符合以下任一条件：%s",
+    "And":                 "%s 不符合全部条件：%s",
+    "Not":                 "%s 不能符合：%s",
+    "Expr":                "%s 不符合条件：%s",
 }
 
 func fetchFieldName(key string) string {
-    if strings.Index(key, ".") >= 0 {
-        return strings.Split(key, ".")[0]
+    if index := strings.LastIndex(key, "."); index >= 0 {
+        return key[:index]
     }
     return key
 }
@@ -72,6 +195,12 @@ type Validator interface {
     GetLimitValue() interface{}
 }
 
+// Reasoner can be implemented by a Validator which can tell why the obj is invalid,
+// the reason is set to Error.Reason, and the message is used if no error description
+type Reasoner interface {
+    Reason(obj interface{}) (reason string, message string)
+}
+
 // Required struct
 type Required struct {
     Key string
@@ -231,7 +360,64 @@ func (r Range) GetLimitValue() interface{} {
     return []int{r.Min.Min, r.Max.Max}
 }
 
-// MinSize Requires an array or string to be at least a given length.
+// SizeMode the mode of MinSizeMode, MaxSizeMode and LengthMode for the strings, the empty mode is SizeRunes
+type SizeMode string
+
+// the modes of MinSize, MaxSize and Length for the strings
+const (
+    // the number of runes, by default
+    SizeRunes SizeMode = "runes"
+    // the number of bytes, such as the limit of a database column
+    SizeBytes SizeMode = "bytes"
+    // the number of user-perceived characters (grapheme clusters), such as an emoji with modifiers is 1
+    SizeGrapheme SizeMode = "grapheme"
+    // the display width in a monospace font, such as a CJK character is 2
+    SizeWidth SizeMode = "width"
+)
+
+// ParseSizeMode parse the mode in the tag, such as the bytes of MaxSize(255,bytes)
+func ParseSizeMode(s string) (SizeMode, error) {
+    switch mode := SizeMode(strings.TrimSpace(s)); mode {
+    case SizeRunes, SizeBytes, SizeGrapheme, SizeWidth:
+        return mode, nil
+    }
+    return "", fmt.Errorf("invalid size mode: %s", s)
+}
+
+// sizeOf return the size of a string by the mode, or the length of a slice, array, map or channel
+func sizeOf(obj interface{}, mode SizeMode) (int, bool) {
+    if str, ok := obj.(string); ok {
+        switch mode {
+        case SizeBytes:
+            return len(str), true
+        case SizeGrapheme:
+            return uniseg.GraphemeClusterCount(str), true
+        case SizeWidth:
+            return uniseg.StringWidth(str), true
+        }
+        return utf8.RuneCountInString(str), true
+    }
+    v := reflect.ValueOf(obj)
+    switch v.Kind() {
+    case reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+        return v.Len(), true
+    }
+    return 0, false
+}
+
+// sizeTmpl return the message template name of the size validators by the mode
+func sizeTmpl(name string, mode SizeMode) string {
+    switch mode {
+    case SizeBytes:
+        return name + "Bytes"
+    case SizeWidth:
+        return name + "Width"
+    }
+    return name
+}
+
+// MinSize Requires an array, slice, map, channel or string to be at least a given length.
+// the size of the string is the number of the runes
 type MinSize struct {
     Min int
     Key string
@@ -239,14 +425,7 @@ type MinSize struct {
 
 // IsSatisfied judge whether obj is valid
 func (m MinSize) IsSatisfied(obj interface{}) bool {
-    if str, ok := obj.(string); ok {
-        return utf8.RuneCountInString(str) >= m.Min
-    }
-    v := reflect.ValueOf(obj)
-    if v.Kind() == reflect.Slice {
-        return v.Len() >= m.Min
-    }
-    return false
+    return MinSizeMode{m.Min, SizeRunes, m.Key}.IsSatisfied(obj)
 }
 
 // DefaultMessage return the default MinSize error message
@@ -264,7 +443,37 @@ func (m MinSize) GetLimitValue() interface{} {
     return m.Min
 }
 
-// MaxSize Requires an array or string to be at most a given length.
+// MinSizeMode Requires an array, slice, map, channel or string to be at least a given length,
+// the size of the string is counted by the mode, such as MinSize(2,width) in the tag
+type MinSizeMode struct {
+    Min  int
+    Mode SizeMode
+    Key  string
+}
+
+// IsSatisfied judge whether obj is valid
+func (m MinSizeMode) IsSatisfied(obj interface{}) bool {
+    size, ok := sizeOf(obj, m.Mode)
+    return ok && size >= m.Min
+}
+
+// DefaultMessage return the default MinSize error message of the mode
+func (m MinSizeMode) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls[sizeTmpl("MinSize", m.Mode)], fetchFieldName(m.Key), m.Min)
+}
+
+// GetKey return the m.Key
+func (m MinSizeMode) GetKey() string {
+    return m.Key
+}
+
+// GetLimitValue return the limit value
+func (m MinSizeMode) GetLimitValue() interface{} {
+    return m.Min
+}
+
+// MaxSize Requires an array, slice, map, channel or string to be at most a given length.
+// the size of the string is the number of the runes
 type MaxSize struct {
     Max int
     Key string
@@ -272,14 +481,7 @@ type MaxSize struct {
 
 // IsSatisfied judge whether obj is valid
 func (m MaxSize) IsSatisfied(obj interface{}) bool {
-    if str, ok := obj.(string); ok {
-        return utf8.RuneCountInString(str) <= m.Max
-    }
-    v := reflect.ValueOf(obj)
-    if v.Kind() == reflect.Slice {
-        return v.Len() <= m.Max
-    }
-    return false
+    return MaxSizeMode{m.Max, SizeRunes, m.Key}.IsSatisfied(obj)
 }
 
 // DefaultMessage return the default MaxSize error message
@@ -297,22 +499,45 @@ func (m MaxSize) GetLimitValue() interface{} {
     return m.Max
 }
 
-// Length Requires an array or string to be exactly a given length.
+// MaxSizeMode Requires an array, slice, map, channel or string to be at most a given length,
+// the size of the string is counted by the mode, such as MaxSize(255,bytes) in the tag
+type MaxSizeMode struct {
+    Max  int
+    Mode SizeMode
+    Key  string
+}
+
+// IsSatisfied judge whether obj is valid
+func (m MaxSizeMode) IsSatisfied(obj interface{}) bool {
+    size, ok := sizeOf(obj, m.Mode)
+    return ok && size <= m.Max
+}
+
+// DefaultMessage return the default MaxSize error message of the mode
+func (m MaxSizeMode) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls[sizeTmpl("MaxSize", m.Mode)], fetchFieldName(m.Key), m.Max)
+}
+
+// GetKey return the m.Key
+func (m MaxSizeMode) GetKey() string {
+    return m.Key
+}
+
+// GetLimitValue return the limit value
+func (m MaxSizeMode) GetLimitValue() interface{} {
+    return m.Max
+}
+
+// Length Requires an array, slice, map, channel or string to be exactly a given length.
+// the size of the string is the number of the runes
 type Length struct {
-    N   int
+    N int
     Key string
 }
 
 // IsSatisfied judge whether obj is valid
 func (l Length) IsSatisfied(obj interface{}) bool {
-    if str, ok := obj.(string); ok {
-        return utf8.RuneCountInString(str) == l.N
-    }
-    v := reflect.ValueOf(obj)
-    if v.Kind() == reflect.Slice {
-        return v.Len() == l.N
-    }
-    return false
+    return LengthMode{l.N, SizeRunes, l.Key}.IsSatisfied(obj)
 }
 
 // DefaultMessage return the default Length error message
@@ -330,6 +555,35 @@ func (l Length) GetLimitValue() interface{} {
     return l.N
 }
 
+// LengthMode Requires an array, slice, map, channel or string to be exactly a given length,
+// the size of the string is counted by the mode, such as Length(6,bytes) in the tag
+type LengthMode struct {
+    N    int
+    Mode SizeMode
+    Key  string
+}
+
+// IsSatisfied judge whether obj is valid
+func (l LengthMode) IsSatisfied(obj interface{}) bool {
+    size, ok := sizeOf(obj, l.Mode)
+    return ok && size == l.N
+}
+
+// DefaultMessage return the default Length error message of the mode
+func (l LengthMode) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls[sizeTmpl("Length", l.Mode)], fetchFieldName(l.Key), l.N)
+}
+
+// GetKey return the l.Key
+func (l LengthMode) GetKey() string {
+    return l.Key
+}
+
+// GetLimitValue return the limit value
+func (l LengthMode) GetLimitValue() interface{} {
+    return l.N
+}
+
 // Alpha check the alpha
 type Alpha struct {
     Key string
@@ -504,84 +758,152 @@ func (a AlphaDash) GetLimitValue() interface{} {
     return nil
 }
 
-var emailPattern = regexp.MustCompile("[\\w!#$%&'*+/=?^_`{|}~-]+(?:\\.[\\w!#$%&'*+/=?^_`{|}~-]+)*@(?:[\\w](?:[\\w-]*[\\w])?\\.)+[a-zA-Z0-9](?:[\\w-]*[\\w])?")
-
-// Email check struct
-type Email struct {
+// Base64 check the string is base64 encoded by decoding it with the standard encoding, the empty string is invalid
+// the embedded Match is kept for compatibility, its Regexp is used instead if it is set
+type Base64 struct {
     Match
     Key string
 }
 
-// DefaultMessage return the default Email error message
-func (e Email) DefaultMessage() string {
-    return fmt.Sprintf(MessageTmpls["Email"], fetchFieldName(e.Key))
-}
-
-// GetKey return the n.Key
-func (e Email) GetKey() string {
-    return e.Key
-}
-
-// GetLimitValue return the limit value
-func (e Email) GetLimitValue() interface{} {
-    return nil
-}
-
-var ipPattern = regexp.MustCompile("^((2[0-4]\\d|25[0-5]|[01]?\\d\\d?)\\.){3}(2[0-4]\\d|25[0-5]|[01]?\\d\\d?)$")
-
-// IP check struct
-type IP struct {
-    Match
-    Key string
+// IsSatisfied judge whether obj is valid
+func (b Base64) IsSatisfied(obj interface{}) bool {
+    if b.Regexp != nil {
+        return b.Match.IsSatisfied(obj)
+    }
+    return Base64WithOptions{Key: b.Key}.IsSatisfied(obj)
 }
 
-// DefaultMessage return the default IP error message
-func (i IP) DefaultMessage() string {
-    return fmt.Sprintf(MessageTmpls["IP"], fetchFieldName(i.Key))
+// DefaultMessage return the default Base64 error message
+func (b Base64) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["Base64"], fetchFieldName(b.Key))
 }
 
-// GetKey return the i.Key
-func (i IP) GetKey() string {
-    return i.Key
+// GetKey return the b.Key
+func (b Base64) GetKey() string {
+    return b.Key
 }
 
 // GetLimitValue return the limit value
-func (i IP) GetLimitValue() interface{} {
+func (b Base64) GetLimitValue() interface{} {
     return nil
 }
 
-var base64Pattern = regexp.MustCompile("^(?:[A-Za-z0-99+/]{4})*(?:[A-Za-z0-9+/]{2}==|[A-Za-z0-9+/]{3}=)?$")
+// Base64WithOptions check the string is base64 encoded as Base64, with the options in the tag,
+// such as Base64(url,maxBytes=1024)
+type Base64WithOptions struct {
+    Options Base64Options
+    Key     string
+}
+
+// Base64Options the options of Base64, the zero value is the standard encoding without byte limits
+// Options in the tag, such as Base64(url,maxBytes=1024):
+//   std          the standard encoding with padding, by default
+//   url          the url safe encoding with padding
+//   rawstd       the standard encoding without padding
+//   rawurl       the url safe encoding without padding
+//   minBytes=N   the min length of the decoded bytes
+//   maxBytes=N   the max length of the decoded bytes
+type Base64Options struct {
+    Encoding *base64.Encoding
+    MinBytes int
+    // 0 means no limit
+    MaxBytes int
+}
+
+// ParseBase64Options parse the options of Base64 in the tag
+func ParseBase64Options(options ...string) (opts Base64Options, err error) {
+    for _, option := range options {
+        option = strings.TrimSpace(option)
+        name, value := option, ""
+        if index := strings.Index(option, "="); index >= 0 {
+            name, value = strings.TrimSpace(option[:index]), strings.TrimSpace(option[index + 1:])
+        }
+        switch name {
+        case "":
+        case "std":
+            opts.Encoding = base64.StdEncoding
+        case "url":
+            opts.Encoding = base64.URLEncoding
+        case "rawstd":
+            opts.Encoding = base64.RawStdEncoding
+        case "rawurl":
+            opts.Encoding = base64.RawURLEncoding
+        case "minBytes", "maxBytes":
+            n, err := strconv.Atoi(value)
+            if err != nil || n < 0 || (name == "maxBytes" && n == 0) {
+                return opts, fmt.Errorf("invalid Base64 option: %s", option)
+            }
+            if name == "minBytes" {
+                opts.MinBytes = n
+            } else {
+                opts.MaxBytes = n
+            }
+        default:
+            return opts, fmt.Errorf("invalid Base64 option: %s", option)
+        }
+    }
+    return
+}
 
-// Base64 check struct
-type Base64 struct {
-    Match
-    Key string
+// IsSatisfied judge whether obj is valid
+func (b Base64WithOptions) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    // the decoder ignores the line breaks, but they are not allowed here
+    if !ok || len(str) == 0 || strings.ContainsAny(str, "\r\n") {
+        return false
+    }
+    encoding := b.Options.Encoding
+    if encoding == nil {
+        encoding = base64.StdEncoding
+    }
+    data, err := encoding.Strict().DecodeString(str)
+    if err != nil || len(data) < b.Options.MinBytes {
+        return false
+    }
+    return b.Options.MaxBytes == 0 || len(data) <= b.Options.MaxBytes
 }
 
 // DefaultMessage return the default Base64 error message
-func (b Base64) DefaultMessage() string {
+func (b Base64WithOptions) DefaultMessage() string {
+    opts := b.Options
+    switch {
+    case opts.MinBytes > 0 && opts.MaxBytes > 0:
+        return fmt.Sprintf(MessageTmpls["Base64Range"], fetchFieldName(b.Key), opts.MinBytes, opts.MaxBytes)
+    case opts.MinBytes > 0:
+        return fmt.Sprintf(MessageTmpls["Base64MinBytes"], fetchFieldName(b.Key), opts.MinBytes)
+    case opts.MaxBytes > 0:
+        return fmt.Sprintf(MessageTmpls["Base64MaxBytes"], fetchFieldName(b.Key), opts.MaxBytes)
+    }
     return fmt.Sprintf(MessageTmpls["Base64"], fetchFieldName(b.Key))
 }
 
 // GetKey return the b.Key
-func (b Base64) GetKey() string {
+func (b Base64WithOptions) GetKey() string {
     return b.Key
 }
 
-// GetLimitValue return the limit value
-func (b Base64) GetLimitValue() interface{} {
-    return nil
+// GetLimitValue return the options
+func (b Base64WithOptions) GetLimitValue() interface{} {
+    return b.Options
 }
 
-// just for chinese mobile phone number
-var mobilePattern = regexp.MustCompile("^((\\+86)|(86))?(1(([35][0-9])|[8][0-9]|[7][06789]|[4][579]))\\d{8}$")
-
-// Mobile check struct
+// Mobile check struct, just for chinese mobile phone number
+// the mobile prefixes are loaded from the CN region of the phone numbering table, see LoadPhoneRegions
+// the embedded Match is kept for compatibility, its Regexp is used instead if it is set
 type Mobile struct {
     Match
     Key string
 }
 
+// IsSatisfied judge whether obj is valid
+func (m Mobile) IsSatisfied(obj interface{}) bool {
+    if m.Regexp != nil {
+        return m.Match.IsSatisfied(obj)
+    }
+    str, ok := obj.(string)
+    return ok && isCNMobile(str)
+}
+
 // DefaultMessage return the default Mobile error message
 func (m Mobile) DefaultMessage() string {
     return fmt.Sprintf(MessageTmpls["Mobile"], fetchFieldName(m.Key))
@@ -648,6 +970,42 @@ func (p Phone) GetLimitValue() interface{} {
     return nil
 }
 
+// PhoneInRegions check the string is the phone number of one of the Regions in the phone numbering table,
+// such as Phone(US,CA) in the tag
+type PhoneInRegions struct {
+    Regions PhoneRegions
+    Key     string
+}
+
+// IsSatisfied judge whether obj is valid
+func (p PhoneInRegions) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    if !ok {
+        return false
+    }
+    for _, region := range p.Regions {
+        if r := getPhoneRegion(region); r != nil && r.match(str) {
+            return true
+        }
+    }
+    return false
+}
+
+// DefaultMessage return the default PhoneInRegions error message
+func (p PhoneInRegions) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["PhoneRegion"], fetchFieldName(p.Key), strings.Join(p.Regions, ","))
+}
+
+// GetKey return the p.Key
+func (p PhoneInRegions) GetKey() string {
+    return p.Key
+}
+
+// GetLimitValue return the limit value
+func (p PhoneInRegions) GetLimitValue() interface{} {
+    return p.Regions
+}
+
 // just for chinese zipcode
 var zipCodePattern = regexp.MustCompile("^[1-9]\\d{5}$")
 
@@ -0,0 +1,428 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "errors"
+    "fmt"
+    "regexp"
+    "strings"
+    "time"
+)
+
+// the province level administrative division codes of china (GB/T 2260)
+var provinceCodes = map[string]string{
+    "11": "北京", "12": "天津", "13": "河北", "14": "山西", "15": "内蒙古",
+    "21": "辽宁", "22": "吉林", "23": "黑龙江",
+    "31": "上海", "32": "江苏", "33": "浙江", "34": "安徽", "35": "福建", "36": "江西", "37": "山东",
+    "41": "河南", "42": "湖北", "43": "湖南", "44": "广东", "45": "广西", "46": "海南",
+    "50": "重庆", "51": "四川", "52": "贵州", "53": "云南", "54": "西藏",
+    "61": "陕西", "62": "甘肃", "63": "青海", "64": "宁夏", "65": "新疆",
+    "71": "台湾", "81": "香港", "82": "澳门",
+}
+
+// the weights and check codes of ISO 7064 MOD 11-2
+var (
+    idCardWeights    = []int{7, 9, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9, 10, 5, 8, 4, 2}
+    idCardCheckCodes = "10X98765432"
+)
+
+// IDCardInfo the information parsed from a chinese resident id card number
+type IDCardInfo struct {
+    // the 6 digits administrative division code
+    RegionCode string
+    // the province name of the region code
+    Province string
+    Birthday time.Time
+    // 1 for male, 2 for female
+    Gender int
+}
+
+// IsMale whether the gender is male
+func (i IDCardInfo) IsMale() bool {
+    return i.Gender == 1
+}
+
+// ParseIDCard parse the 18 digits or legacy 15 digits chinese resident id card number,
+// and check the region code, birthday and the check digit of 18 digits number
+func ParseIDCard(id string) (info IDCardInfo, err error) {
+    id = strings.ToUpper(id)
+    var birth string
+    switch len(id) {
+    case 18:
+        if !isDigits(id[:17]) {
+            err = errors.New("id card number must be digits")
+            return
+        }
+        sum := 0
+        for i := 0; i < 17; i++ {
+            sum += int(id[i] - '0') * idCardWeights[i]
+        }
+        if idCardCheckCodes[sum % 11] != id[17] {
+            err = errors.New("invalid check digit of id card number")
+            return
+        }
+        birth = id[6:14]
+    case 15:
+        if !isDigits(id) {
+            err = errors.New("id card number must be digits")
+            return
+        }
+        birth = "19" + id[6:12]
+    default:
+        err = errors.New("id card number must be 15 or 18 digits")
+        return
+    }
+
+    info.RegionCode = id[:6]
+    var ok bool
+    if info.Province, ok = provinceCodes[id[:2]]; !ok {
+        err = fmt.Errorf("invalid region code %s", info.RegionCode)
+        return
+    }
+    if info.Birthday, err = time.ParseInLocation("20060102", birth, time.Local); err != nil {
+        err = fmt.Errorf("invalid birthday %s", birth)
+        return
+    }
+    if info.Birthday.After(time.Now()) || info.Birthday.Year() < 1900 {
+        err = fmt.Errorf("invalid birthday %s", birth)
+        return
+    }
+    // the last digit of the sequence code, odd for male and even for female
+    seq := id[14]
+    if len(id) == 18 {
+        seq = id[16]
+    }
+    info.Gender = 2 - int(seq - '0') % 2
+    return
+}
+
+// IDCard check the string is a chinese resident id card number (身份证号)
+type IDCard struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (i IDCard) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    if !ok {
+        return false
+    }
+    _, err := ParseIDCard(str)
+    return err == nil
+}
+
+// DefaultMessage return the default IDCard error message
+func (i IDCard) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["IDCard"], fetchFieldName(i.Key))
+}
+
+// GetKey return the i.Key
+func (i IDCard) GetKey() string {
+    return i.Key
+}
+
+// GetLimitValue return the limit value
+func (i IDCard) GetLimitValue() interface{} {
+    return nil
+}
+
+// IDCard Test that the obj is chinese resident id card number if type is string
+func (v *Validation) IDCard(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(IDCard{key}, obj, errDesc)
+}
+
+// the characters and weights of the unified social credit code (GB 32100)
+var (
+    usccChars   = "0123456789ABCDEFGHJKLMNPQRTUWXY"
+    usccWeights = []int{1, 3, 9, 27, 19, 26, 16, 17, 20, 29, 25, 13, 8, 24, 10, 30, 28}
+)
+
+// the weights of the organization code (GB 11714)
+var orgCodeWeights = []int{3, 7, 9, 10, 5, 8, 4, 2}
+
+// isOrgCode judge whether code is a 9 characters organization code without the dash
+func isOrgCode(code string) bool {
+    if len(code) != 9 {
+        return false
+    }
+    sum := 0
+    for i := 0; i < 8; i++ {
+        c := code[i]
+        switch {
+        case '0' <= c && c <= '9':
+            sum += int(c - '0') * orgCodeWeights[i]
+        case 'A' <= c && c <= 'Z':
+            sum += int(c - 'A' + 10) * orgCodeWeights[i]
+        default:
+            return false
+        }
+    }
+    switch check := 11 - sum % 11; check {
+    case 10:
+        return code[8] == 'X'
+    case 11:
+        return code[8] == '0'
+    default:
+        return int(code[8] - '0') == check
+    }
+}
+
+// isUSCC judge whether code is an 18 characters unified social credit code
+func isUSCC(code string) bool {
+    if len(code) != 18 || !isDigits(code[2:8]) {
+        return false
+    }
+    sum := 0
+    for i := 0; i < 17; i++ {
+        index := strings.IndexByte(usccChars, code[i])
+        if index == -1 {
+            return false
+        }
+        sum += index * usccWeights[i]
+    }
+    if usccChars[(31 - sum % 31) % 31] != code[17] {
+        return false
+    }
+    return isOrgCode(code[8:17])
+}
+
+// luhn judge whether s is digits and pass the luhn checksum
+func luhn(s string) bool {
+    if !isDigits(s) {
+        return false
+    }
+    sum := 0
+    double := false
+    for i := len(s) - 1; i >= 0; i-- {
+        d := int(s[i] - '0')
+        if double {
+            if d *= 2; d > 9 {
+                d -= 9
+            }
+        }
+        sum += d
+        double = !double
+    }
+    return sum % 10 == 0
+}
+
+// binRange the length of the card number whose BIN(the leading digits) is between Low and High
+type binRange struct {
+    Low, High int
+    MinLength int
+    MaxLength int
+}
+
+// the length of the card numbers by the first 4 digits of BIN,
+// the card number which is not in the ranges must be 16 to 19 digits
+var bankCardBins = []binRange{
+    {3400, 3499, 15, 15}, // American Express
+    {3700, 3799, 15, 15}, // American Express
+    {3528, 3589, 16, 19}, // JCB
+    {4000, 4999, 13, 19}, // Visa
+    {2221, 2720, 16, 16}, // Mastercard
+    {5100, 5599, 16, 16}, // Mastercard
+    {6200, 6299, 16, 19}, // UnionPay
+}
+
+func isBankCard(s string) bool {
+    if !luhn(s) || len(s) < 13 {
+        return false
+    }
+    bin := int(s[0] - '0') * 1000 + int(s[1] - '0') * 100 + int(s[2] - '0') * 10 + int(s[3] - '0')
+    for _, r := range bankCardBins {
+        if r.Low <= bin && bin <= r.High {
+            return r.MinLength <= len(s) && len(s) <= r.MaxLength
+        }
+    }
+    return 16 <= len(s) && len(s) <= 19
+}
+
+// USCC check the string is an 18 characters unified social credit code (统一社会信用代码)
+type USCC struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (u USCC) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    return ok && isUSCC(str)
+}
+
+// DefaultMessage return the default USCC error message
+func (u USCC) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["USCC"], fetchFieldName(u.Key))
+}
+
+// GetKey return the u.Key
+func (u USCC) GetKey() string {
+    return u.Key
+}
+
+// GetLimitValue return the limit value
+func (u USCC) GetLimitValue() interface{} {
+    return nil
+}
+
+// OrgCode check the string is an organization code (组织机构代码), such as 12345678-X or 12345678X
+type OrgCode struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (o OrgCode) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    if !ok {
+        return false
+    }
+    if len(str) == 10 && str[8] == '-' {
+        str = str[:8] + str[9:]
+    }
+    return isOrgCode(str)
+}
+
+// DefaultMessage return the default OrgCode error message
+func (o OrgCode) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["OrgCode"], fetchFieldName(o.Key))
+}
+
+// GetKey return the o.Key
+func (o OrgCode) GetKey() string {
+    return o.Key
+}
+
+// GetLimitValue return the limit value
+func (o OrgCode) GetLimitValue() interface{} {
+    return nil
+}
+
+// BankCard check the string is a bank card number, which pass the luhn checksum
+// and the length is valid for the BIN
+type BankCard struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (b BankCard) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    return ok && isBankCard(str)
+}
+
+// DefaultMessage return the default BankCard error message
+func (b BankCard) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["BankCard"], fetchFieldName(b.Key))
+}
+
+// GetKey return the b.Key
+func (b BankCard) GetKey() string {
+    return b.Key
+}
+
+// GetLimitValue return the limit value
+func (b BankCard) GetLimitValue() interface{} {
+    return nil
+}
+
+// isRegionCodeFormat judge whether code has the format of a 6 digits administrative division code of china (GB/T 2260),
+// such as 110000 for a province, 110100 for a city and 110105 for a county. Only the province is checked
+// against the table, the city and county part are not, so 119999 passes although it doesn't exist.
+// The county part must be 00 if the city part is 00
+func isRegionCodeFormat(code string) bool {
+    if len(code) != 6 || !isDigits(code) {
+        return false
+    }
+    if _, ok := provinceCodes[code[:2]]; !ok {
+        return false
+    }
+    return code[2:4] != "00" || code[4:6] == "00"
+}
+
+// RegionCodeFormat check the string has the format of a 6 digits administrative division code of china (行政区划代码),
+// it doesn't check the code exists, use In or Enum with the division table for that
+type RegionCodeFormat struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (r RegionCodeFormat) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    return ok && isRegionCodeFormat(str)
+}
+
+// DefaultMessage return the default RegionCodeFormat error message
+func (r RegionCodeFormat) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["RegionCodeFormat"], fetchFieldName(r.Key))
+}
+
+// GetKey return the r.Key
+func (r RegionCodeFormat) GetKey() string {
+    return r.Key
+}
+
+// GetLimitValue return the limit value
+func (r RegionCodeFormat) GetLimitValue() interface{} {
+    return nil
+}
+
+// just for chinese vehicle license plate, including the new energy plate
+var licensePlatePattern = regexp.MustCompile("^[京津沪渝冀豫云辽黑湘皖鲁新苏浙赣鄂桂甘晋蒙陕吉闽贵粤青藏川宁琼][A-HJ-NP-Z]" +
+    "(?:[A-HJ-NP-Z0-9]{4}[A-HJ-NP-Z0-9挂学警港澳]|[A-HJK][A-HJ-NP-Z0-9][0-9]{4}|[0-9]{5}[DF])$")
+
+// LicensePlate check the vehicle license plate struct
+type LicensePlate struct {
+    Match
+    Key string
+}
+
+// DefaultMessage return the default LicensePlate error message
+func (l LicensePlate) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["LicensePlate"], fetchFieldName(l.Key))
+}
+
+// GetKey return the l.Key
+func (l LicensePlate) GetKey() string {
+    return l.Key
+}
+
+// GetLimitValue return the limit value
+func (l LicensePlate) GetLimitValue() interface{} {
+    return nil
+}
+
+// USCC Test that the obj is unified social credit code if type is string
+func (v *Validation) USCC(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(USCC{key}, obj, errDesc)
+}
+
+// OrgCode Test that the obj is organization code if type is string
+func (v *Validation) OrgCode(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(OrgCode{key}, obj, errDesc)
+}
+
+// BankCard Test that the obj is bank card number if type is string
+func (v *Validation) BankCard(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(BankCard{key}, obj, errDesc)
+}
+
+// RegionCodeFormat Test that the obj has the format of administrative division code of china if type is string
+func (v *Validation) RegionCodeFormat(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(RegionCodeFormat{key}, obj, errDesc)
+}
+
+// LicensePlate Test that the obj is chinese vehicle license plate if type is string
+func (v *Validation) LicensePlate(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(LicensePlate{Match{Regexp: licensePlatePattern}, key}, obj, errDesc)
+}
@@ -0,0 +1,150 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "testing"
+)
+
+func TestParseIDCard(t *testing.T) {
+    tests := []struct {
+        id       string
+        ok       bool
+        province string
+        birthday string
+        gender   int
+    }{
+        {"11010519491231002X", true, "北京", "1949-12-31", 2},
+        {"11010519491231002x", true, "北京", "1949-12-31", 2},
+        {"440306199001010014", true, "广东", "1990-01-01", 1},
+        {"32010220000229003X", true, "江苏", "2000-02-29", 1},
+        // the legacy 15 digits number, the year is 19xx
+        {"110105491231002", true, "北京", "1949-12-31", 2},
+        // the check digit
+        {"110105194912310021", false, "", "", 0},
+        {"440306199001010015", false, "", "", 0},
+        // the region code
+        {"990105194912310029", false, "", "", 0},
+        // the birthday
+        {"110105491331002", false, "", "", 0},
+        {"11010520300101001X", false, "", "", 0},
+        {"110105189901010017", false, "", "", 0},
+        // the length and digits
+        {"1101051949123100", false, "", "", 0},
+        {"1101051949A231002X", false, "", "", 0},
+        {"", false, "", "", 0},
+    }
+    for _, test := range tests {
+        info, err := ParseIDCard(test.id)
+        if (err == nil) != test.ok {
+            t.Errorf("ParseIDCard(%q) error = %v, want ok %v", test.id, err, test.ok)
+            continue
+        }
+        if !test.ok {
+            continue
+        }
+        if info.Province != test.province || info.Birthday.Format("2006-01-02") != test.birthday ||
+            info.Gender != test.gender || info.RegionCode != test.id[:6] {
+            t.Errorf("ParseIDCard(%q) = %+v", test.id, info)
+        }
+    }
+}
+
+func TestIDCard(t *testing.T) {
+    tests := []struct {
+        obj  interface{}
+        want bool
+    }{
+        {"11010519491231002X", true},
+        {"440306199001010014", true},
+        {"110105491231002", true},
+        {"110105194912310021", false},
+        {"", false},
+        {110105491231002, false},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        if valid.IDCard(test.obj, "id.IDCard", "").Ok != test.want {
+            t.Errorf("IDCard(%v) should be %v", test.obj, test.want)
+        }
+    }
+}
+
+func TestCheckCodes(t *testing.T) {
+    tests := []struct {
+        name string
+        obj  interface{}
+        want bool
+    }{
+        {"USCC", "91350100M000100Y43", true},
+        {"USCC", "91350100M000100Y44", false},
+        // the organization code part is checked too
+        {"USCC", "91350100M000100Y56", false},
+        {"USCC", "91350100m000100Y43", false},
+        {"USCC", "91350100M000100Y4", false},
+        {"USCC", "", false},
+        {"OrgCode", "D2143569-X", true},
+        {"OrgCode", "D2143569X", true},
+        {"OrgCode", "M000100Y4", true},
+        {"OrgCode", "M000100Y5", false},
+        {"OrgCode", "D2143569-1", false},
+        {"OrgCode", "D214356-9X", false},
+        {"BankCard", "6225880137706868", true},
+        {"BankCard", "4111111111111111", true},
+        {"BankCard", "5555555555554444", true},
+        {"BankCard", "378282246310005", true},
+        // American Express is 15 digits
+        {"BankCard", "3782822463100050", false},
+        {"BankCard", "6222021234567890", false},
+        {"BankCard", "6225 8801 3770 6868", false},
+        {"BankCard", "", false},
+        {"LicensePlate", "京A12345", true},
+        {"LicensePlate", "粤BD12345", true},
+        {"LicensePlate", "粤B12345D", true},
+        {"LicensePlate", "京A1234学", true},
+        // I and O are not used
+        {"LicensePlate", "京AI2345", false},
+        {"LicensePlate", "京A1234", false},
+        {"LicensePlate", "A12345", false},
+        {"RegionCodeFormat", "110000", true},
+        {"RegionCodeFormat", "110100", true},
+        {"RegionCodeFormat", "110105", true},
+        // only the format is checked, the city and county don't have to exist
+        {"RegionCodeFormat", "119999", true},
+        {"RegionCodeFormat", "110005", false},
+        {"RegionCodeFormat", "990000", false},
+        {"RegionCodeFormat", "11010", false},
+        {"RegionCodeFormat", "11010a", false},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        var r *Result
+        switch test.name {
+        case "USCC":
+            r = valid.USCC(test.obj, "code.USCC", "")
+        case "OrgCode":
+            r = valid.OrgCode(test.obj, "code.OrgCode", "")
+        case "BankCard":
+            r = valid.BankCard(test.obj, "code.BankCard", "")
+        case "LicensePlate":
+            r = valid.LicensePlate(test.obj, "code.LicensePlate", "")
+        case "RegionCodeFormat":
+            r = valid.RegionCodeFormat(test.obj, "code.RegionCodeFormat", "")
+        }
+        if r.Ok != test.want {
+            t.Errorf("%s(%v) should be %v", test.name, test.obj, test.want)
+        }
+    }
+}
@@ -0,0 +1,452 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "fmt"
+    "reflect"
+    "sort"
+    "strings"
+)
+
+// isCollection judge whether v is a slice, array or map
+func isCollection(v reflect.Value) bool {
+    switch v.Kind() {
+    case reflect.Slice, reflect.Array, reflect.Map:
+        return true
+    }
+    return false
+}
+
+// items return the elements of a slice or array, or the keys of a map
+func items(v reflect.Value) []reflect.Value {
+    if v.Kind() == reflect.Map {
+        return sortedKeys(v)
+    }
+    values := make([]reflect.Value, v.Len())
+    for i := range values {
+        values[i] = v.Index(i)
+    }
+    return values
+}
+
+// sortedKeys return the keys of the map in a stable order, so the first error is always the same one
+func sortedKeys(v reflect.Value) []reflect.Value {
+    keys := v.MapKeys()
+    sort.Slice(keys, func(i, j int) bool {
+        return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+    })
+    return keys
+}
+
+// uniqueKey return a comparable key of the value, the pointers are dereferenced
+func uniqueKey(v reflect.Value) interface{} {
+    for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+        if v.IsNil() {
+            return nil
+        }
+        v = v.Elem()
+    }
+    if v.Type().Comparable() {
+        return v.Interface()
+    }
+    return fmt.Sprintf("%#v", v.Interface())
+}
+
+// Unique check the elements of a slice or array, or the values of a map are unique,
+// or the Field of the elements if it is not empty, the elements must be structs or struct pointers
+type Unique struct {
+    Field UniqueField
+    Key   string
+}
+
+// UniqueField the field of the elements compared by Unique, empty to compare the elements
+type UniqueField string
+
+// ParseUniqueField parse the optional field of Unique in the tag, such as the SKU of Unique(SKU)
+func ParseUniqueField(fields ...string) (UniqueField, error) {
+    if len(fields) > 1 {
+        return "", fmt.Errorf("Unique require at most 1 parameters")
+    }
+    return UniqueField(strings.TrimSpace(strings.Join(fields, ""))), nil
+}
+
+// checkUniqueField check the field of Unique exists in the elements of the collection type t,
+// the elements of interface type are checked by the values
+func checkUniqueField(t reflect.Type, field UniqueField) error {
+    if len(field) == 0 {
+        return nil
+    }
+    for t.Kind() == reflect.Ptr {
+        t = t.Elem()
+    }
+    switch t.Kind() {
+    case reflect.Slice, reflect.Array, reflect.Map:
+    default:
+        return nil
+    }
+    elem := t.Elem()
+    for elem.Kind() == reflect.Ptr {
+        elem = elem.Elem()
+    }
+    switch elem.Kind() {
+    case reflect.Interface:
+        return nil
+    case reflect.Struct:
+        // the unexported field can't be read
+        if f, ok := elem.FieldByName(string(field)); ok && len(f.PkgPath) == 0 {
+            return nil
+        }
+        return fmt.Errorf("doesn't exist %s field", field)
+    }
+    return fmt.Errorf("Unique(%s) require the elements to be structs", field)
+}
+
+// IsSatisfied judge whether obj is valid
+func (u Unique) IsSatisfied(obj interface{}) bool {
+    v := reflect.ValueOf(obj)
+    if !isCollection(v) {
+        return false
+    }
+    var values []reflect.Value
+    if v.Kind() == reflect.Map {
+        for _, key := range sortedKeys(v) {
+            values = append(values, v.MapIndex(key))
+        }
+    } else {
+        values = items(v)
+    }
+    seen := make(map[interface{}]bool, len(values))
+    // the index of the field is looked up once for each element type
+    var (
+        elemType reflect.Type
+        index    []int
+    )
+    for _, value := range values {
+        if len(u.Field) > 0 {
+            for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+                value = value.Elem()
+            }
+            if value.Kind() != reflect.Struct {
+                return false
+            }
+            if value.Type() != elemType {
+                f, ok := value.Type().FieldByName(string(u.Field))
+                if !ok || len(f.PkgPath) > 0 {
+                    return false
+                }
+                elemType, index = value.Type(), f.Index
+            }
+            var err error
+            // the field of a nil embedded pointer or an unexported embedded struct can't be compared
+            if value, err = value.FieldByIndexErr(index); err != nil || !value.CanInterface() {
+                return false
+            }
+        }
+        key := uniqueKey(value)
+        if seen[key] {
+            return false
+        }
+        seen[key] = true
+    }
+    return true
+}
+
+// DefaultMessage return the default Unique error message
+func (u Unique) DefaultMessage() string {
+    if len(u.Field) == 0 {
+        return fmt.Sprintf(MessageTmpls["Unique"], fetchFieldName(u.Key))
+    }
+    return fmt.Sprintf(MessageTmpls["UniqueField"], fetchFieldName(u.Key), string(u.Field))
+}
+
+// GetKey return the u.Key
+func (u Unique) GetKey() string {
+    return u.Key
+}
+
+// GetLimitValue return the limit value
+func (u Unique) GetLimitValue() interface{} {
+    return u.Field
+}
+
+// ContainsItem check a slice or array contains the item, or a map contains the key,
+// the item is converted to the type of the elements, such as ContainsItem(3) for []int
+type ContainsItem struct {
+    Item string
+    Key  string
+}
+
+// IsSatisfied judge whether obj is valid
+func (c ContainsItem) IsSatisfied(obj interface{}) bool {
+    v := reflect.ValueOf(obj)
+    if !isCollection(v) {
+        return false
+    }
+    values := []interface{}{c.Item}
+    for _, item := range items(v) {
+        if inValues(item.Interface(), values, false) {
+            return true
+        }
+    }
+    return false
+}
+
+// DefaultMessage return the default ContainsItem error message
+func (c ContainsItem) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["ContainsItem"], fetchFieldName(c.Key), c.Item)
+}
+
+// GetKey return the c.Key
+func (c ContainsItem) GetKey() string {
+    return c.Key
+}
+
+// GetLimitValue return the limit value
+func (c ContainsItem) GetLimitValue() interface{} {
+    return c.Item
+}
+
+// SubsetOf check every element of a slice or array, or every key of a map is one of the values
+type SubsetOf struct {
+    Values []string
+    Key    string
+}
+
+// IsSatisfied judge whether obj is valid
+func (s SubsetOf) IsSatisfied(obj interface{}) bool {
+    v := reflect.ValueOf(obj)
+    if !isCollection(v) {
+        return false
+    }
+    values := stringValues(s.Values)
+    for _, item := range items(v) {
+        if !inValues(item.Interface(), values, false) {
+            return false
+        }
+    }
+    return true
+}
+
+// DefaultMessage return the default SubsetOf error message
+func (s SubsetOf) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["SubsetOf"], fetchFieldName(s.Key), strings.Join(s.Values, ","))
+}
+
+// GetKey return the s.Key
+func (s SubsetOf) GetKey() string {
+    return s.Key
+}
+
+// GetLimitValue return the limit value
+func (s SubsetOf) GetLimitValue() interface{} {
+    return s.Values
+}
+
+// MinItems Requires a slice, array or map to have at least Min elements
+type MinItems struct {
+    Min int
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (m MinItems) IsSatisfied(obj interface{}) bool {
+    v := reflect.ValueOf(obj)
+    return isCollection(v) && v.Len() >= m.Min
+}
+
+// DefaultMessage return the default MinItems error message
+func (m MinItems) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["MinItems"], fetchFieldName(m.Key), m.Min)
+}
+
+// GetKey return the m.Key
+func (m MinItems) GetKey() string {
+    return m.Key
+}
+
+// GetLimitValue return the limit value
+func (m MinItems) GetLimitValue() interface{} {
+    return m.Min
+}
+
+// MaxItems Requires a slice, array or map to have at most Max elements
+type MaxItems struct {
+    Max int
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (m MaxItems) IsSatisfied(obj interface{}) bool {
+    v := reflect.ValueOf(obj)
+    return isCollection(v) && v.Len() <= m.Max
+}
+
+// DefaultMessage return the default MaxItems error message
+func (m MaxItems) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["MaxItems"], fetchFieldName(m.Key), m.Max)
+}
+
+// GetKey return the m.Key
+func (m MaxItems) GetKey() string {
+    return m.Key
+}
+
+// GetLimitValue return the limit value
+func (m MaxItems) GetLimitValue() interface{} {
+    return m.Max
+}
+
+// Keys check the obj is a map, the rules of the keys are applied by Validation.Keys
+type Keys struct {
+    Rules Rules
+    Key   string
+}
+
+// IsSatisfied judge whether obj is valid
+func (k Keys) IsSatisfied(obj interface{}) bool {
+    return reflect.ValueOf(obj).Kind() == reflect.Map
+}
+
+// DefaultMessage return the default Keys error message
+func (k Keys) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["Keys"], fetchFieldName(k.Key))
+}
+
+// GetKey return the k.Key
+func (k Keys) GetKey() string {
+    return k.Key
+}
+
+// GetLimitValue return the limit value
+func (k Keys) GetLimitValue() interface{} {
+    return nil
+}
+
+// Values check the obj is a map, slice or array, the rules of the values are applied by Validation.Values
+type Values struct {
+    Rules Rules
+    Key   string
+}
+
+// IsSatisfied judge whether obj is valid
+func (v Values) IsSatisfied(obj interface{}) bool {
+    return isCollection(reflect.ValueOf(obj))
+}
+
+// DefaultMessage return the default Values error message
+func (v Values) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["Values"], fetchFieldName(v.Key))
+}
+
+// GetKey return the v.Key
+func (v Values) GetKey() string {
+    return v.Key
+}
+
+// GetLimitValue return the limit value
+func (v Values) GetLimitValue() interface{} {
+    return nil
+}
+
+// applyRules apply the nested rules to the obj with the indexed path, such as Tags[0] or Scores[math],
+// and return the error of the first failed rule
+func (v *Validation) applyRules(rules Rules, obj interface{}, path, errDesc string) *Result {
+    for _, vf := range rules {
+        params := append([]interface{}{}, vf.Params...)
+        params[len(params) - 1] = path + "." + ruleName(vf.Name)
+        n := len(v.Errors)
+        if _, err := funcs.Call(vf.Name, mergeParam(v, obj, vf.ErrMsg, params)...); err != nil {
+            return v.ruleError(params[len(params) - 1].(string), err)
+        }
+        if len(v.Errors) > n {
+            err := v.Errors[n]
+            if strings.TrimSpace(errDesc) != "" {
+                err.Message = errDesc
+            }
+            return &Result{Error: err}
+        }
+    }
+    return &Result{Ok: true}
+}
+
+// Unique Test that the elements of obj are unique if type is slice, array or map,
+// the field of the elements is compared if it is given, such as Unique(SKU)
+func (v *Validation) Unique(obj interface{}, field UniqueField, key string, errDesc string) *Result {
+    // the field is checked once by the type, it is also checked when the tag is compiled
+    if t := reflect.TypeOf(obj); t != nil {
+        if err := checkUniqueField(t, field); err != nil {
+            return v.ruleError(key, err)
+        }
+    }
+    return v.apply(Unique{field, key}, obj, errDesc)
+}
+
+// ContainsItem Test that the obj contains the item if type is slice or array, or the key if type is map
+func (v *Validation) ContainsItem(obj interface{}, item string, key string, errDesc string) *Result {
+    return v.apply(ContainsItem{item, key}, obj, errDesc)
+}
+
+// SubsetOf Test that the elements of obj are in the values if type is slice or array, or the keys if type is map
+func (v *Validation) SubsetOf(obj interface{}, values []string, key string, errDesc string) *Result {
+    return v.apply(SubsetOf{values, key}, obj, errDesc)
+}
+
+// MinItems Test that the obj has at least min elements if type is slice, array or map
+func (v *Validation) MinItems(obj interface{}, min int, key string, errDesc string) *Result {
+    return v.apply(MinItems{min, key}, obj, errDesc)
+}
+
+// MaxItems Test that the obj has at most max elements if type is slice, array or map
+func (v *Validation) MaxItems(obj interface{}, max int, key string, errDesc string) *Result {
+    return v.apply(MaxItems{max, key}, obj, errDesc)
+}
+
+// Keys Test that the keys of obj match the rules if type is map, such as Keys(AlphaDash,MaxSize(10)),
+// the key of the error is the indexed path, such as Tags[a-b].AlphaDash
+func (v *Validation) Keys(obj interface{}, rules Rules, key string, errDesc string) *Result {
+    if result := v.apply(Keys{rules, key}, obj, errDesc); !result.Ok {
+        return result
+    }
+    field := fetchFieldName(key)
+    for _, k := range sortedKeys(reflect.ValueOf(obj)) {
+        path := fmt.Sprintf("%s[%v]", field, k.Interface())
+        if result := v.applyRules(rules, k.Interface(), path, errDesc); !result.Ok {
+            return result
+        }
+    }
+    return &Result{Ok: true}
+}
+
+// Values Test that the values of obj match the rules if type is map, slice or array, such as Values(Range(0,100)),
+// the key of the error is the indexed path, such as Scores[math].Range or Items[0].Range
+func (v *Validation) Values(obj interface{}, rules Rules, key string, errDesc string) *Result {
+    if result := v.apply(Values{rules, key}, obj, errDesc); !result.Ok {
+        return result
+    }
+    field := fetchFieldName(key)
+    rv := reflect.ValueOf(obj)
+    for i, item := range items(rv) {
+        path := fmt.Sprintf("%s[%d]", field, i)
+        if rv.Kind() == reflect.Map {
+            path = fmt.Sprintf("%s[%v]", field, item.Interface())
+            item = rv.MapIndex(item)
+        }
+        if result := v.applyRules(rules, item.Interface(), path, errDesc); !result.Ok {
+            return result
+        }
+    }
+    return &Result{Ok: true}
+}
@@ -0,0 +1,205 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "testing"
+)
+
+type uniqueItem struct {
+    SKU  string
+    Name string
+    sku  string
+}
+
+type uniqueBase struct {
+    ID int
+}
+
+type uniqueEmbedded struct {
+    *uniqueBase
+    Name string
+}
+
+func TestUnique(t *testing.T) {
+    tests := []struct {
+        obj   interface{}
+        field UniqueField
+        want  bool
+    }{
+        {[]int{1, 2, 3}, "", true},
+        {[]int{1, 2, 1}, "", false},
+        {[2]string{"a", "a"}, "", false},
+        {map[string]int{"a": 1, "b": 2}, "", true},
+        {map[string]int{"a": 1, "b": 1}, "", false},
+        {[]*int{new(int), new(int)}, "", false},
+        {[][]int{{1}, {1}}, "", false},
+        {[]int{}, "", true},
+        {"abc", "", false},
+        {[]uniqueItem{{SKU: "a"}, {SKU: "b"}}, "SKU", true},
+        {[]uniqueItem{{SKU: "a", Name: "x"}, {SKU: "a", Name: "y"}}, "SKU", false},
+        {[]*uniqueItem{{SKU: "a"}, {SKU: "b"}}, "SKU", true},
+        {[]interface{}{uniqueItem{SKU: "a"}, &uniqueItem{SKU: "a"}}, "SKU", false},
+        {[]interface{}{uniqueItem{SKU: "a"}, 1}, "SKU", false},
+        // the nil embedded pointer doesn't panic
+        {[]uniqueEmbedded{{&uniqueBase{1}, "a"}, {nil, "b"}}, "ID", false},
+        {[]uniqueEmbedded{{&uniqueBase{1}, "a"}, {&uniqueBase{2}, "b"}}, "ID", true},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        if valid.Unique(test.obj, test.field, "items.Unique", "").Ok != test.want {
+            t.Errorf("Unique(%v, %s) should be %v", test.obj, test.field, test.want)
+        }
+    }
+}
+
+func TestUniqueTag(t *testing.T) {
+    type order struct {
+        Items []uniqueItem `valid:"Unique(SKU)"`
+    }
+    valid := Validation{}
+    valid.Valid(order{[]uniqueItem{{SKU: "a"}, {SKU: "a"}}})
+    if !valid.HasErrors() || valid.Errors[0].Key != "Items.Unique" {
+        t.Errorf("the duplicate SKU should be reported, errors = %v", valid.Errors)
+    }
+
+    type missing struct {
+        Items []uniqueItem `valid:"Unique(Price)"`
+    }
+    type unexported struct {
+        Items []uniqueItem `valid:"Unique(sku)"`
+    }
+    type notStruct struct {
+        Items []int `valid:"Unique(SKU)"`
+    }
+    for _, obj := range []interface{}{missing{}, unexported{}, notStruct{}} {
+        if err := (&Validation{}).Valid(obj); err == nil {
+            t.Errorf("the invalid field of Unique in %T should be reported by Valid", obj)
+        }
+    }
+    // the unexported field is checked when the validator is applied directly and doesn't panic
+    valid = Validation{}
+    if valid.Unique([]interface{}{uniqueItem{sku: "a"}, uniqueItem{sku: "a"}}, "sku", "items.Unique", "").Ok {
+        t.Error("the unexported field of Unique should be invalid")
+    }
+}
+
+func TestContainsItem(t *testing.T) {
+    tests := []struct {
+        obj  interface{}
+        item string
+        want bool
+    }{
+        {[]string{"admin", "user"}, "admin", true},
+        {[]string{"user"}, "admin", false},
+        {[]int{1, 2, 3}, "3", true},
+        {[]int{1, 2, 3}, "4", false},
+        {[3]float64{1.5, 2, 3}, "1.5", true},
+        {map[string]bool{"admin": true}, "admin", true},
+        {map[string]bool{"user": true}, "admin", false},
+        {map[int]string{1: "admin"}, "1", true},
+        {[]string{}, "admin", false},
+        {"admin", "admin", false},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        if valid.ContainsItem(test.obj, test.item, "roles.ContainsItem", "").Ok != test.want {
+            t.Errorf("ContainsItem(%v, %s) should be %v", test.obj, test.item, test.want)
+        }
+    }
+}
+
+func TestSubsetOf(t *testing.T) {
+    tests := []struct {
+        obj    interface{}
+        values []string
+        want   bool
+    }{
+        {[]string{"read", "write"}, []string{"read", "write", "admin"}, true},
+        {[]string{"read", "delete"}, []string{"read", "write"}, false},
+        {[]string{}, []string{"read"}, true},
+        {[]int{1, 2}, []string{"1", "2", "3"}, true},
+        {[]int{1, 4}, []string{"1", "2", "3"}, false},
+        {map[string]int{"read": 1}, []string{"read", "write"}, true},
+        {map[string]int{"exec": 1}, []string{"read", "write"}, false},
+        {"read", []string{"read"}, false},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        if valid.SubsetOf(test.obj, test.values, "perms.SubsetOf", "").Ok != test.want {
+            t.Errorf("SubsetOf(%v, %v) should be %v", test.obj, test.values, test.want)
+        }
+    }
+}
+
+func TestItems(t *testing.T) {
+    valid := Validation{}
+    for obj, want := range map[interface{}]bool{"a": false, 1: false} {
+        if valid.MinItems(obj, 0, "items.MinItems", "").Ok != want {
+            t.Errorf("MinItems(%v) should be %v", obj, want)
+        }
+    }
+    if !valid.MinItems([]int{1, 2}, 2, "items.MinItems", "").Ok || valid.MinItems([]int{1}, 2, "items.MinItems", "").Ok {
+        t.Error("MinItems should count the elements")
+    }
+    if !valid.MaxItems(map[string]int{"a": 1}, 1, "items.MaxItems", "").Ok || valid.MaxItems([]int{1, 2}, 1, "items.MaxItems", "").Ok {
+        t.Error("MaxItems should count the elements")
+    }
+}
+
+func TestNestedKeys(t *testing.T) {
+    type form struct {
+        Tags   map[string]int      `valid:"Keys(AlphaDash,MaxSize(5))"`
+        Scores map[string]int      `valid:"Values(Range(0,100))"`
+        Items  []string            `valid:"Values(Required,MaxSize(3))"`
+        Matrix [][]int             `valid:"Values(Values(Min(0)))"`
+        Groups map[string][]string `valid:"Values(Values(Alpha))"`
+    }
+    tests := []struct {
+        f   form
+        key string
+    }{
+        {form{}, ""},
+        {form{Tags: map[string]int{"ok": 1, "a b": 2}}, "Tags[a b].AlphaDash"},
+        {form{Tags: map[string]int{"toolong": 1}}, "Tags[toolong].MaxSize"},
+        {form{Scores: map[string]int{"math": 90, "art": 101}}, "Scores[art].Range"},
+        {form{Items: []string{"a", ""}}, "Items[1].Required"},
+        {form{Items: []string{"a", "abcd"}}, "Items[1].MaxSize"},
+        {form{Matrix: [][]int{{1}, {2, -1}}}, "Matrix[1][1].Min"},
+        {form{Groups: map[string][]string{"x": {"a", "b1"}}}, "Groups[x][1].Alpha"},
+    }
+    for _, test := range tests {
+        valid := Validation{}
+        if err := valid.Valid(test.f); err != nil && !valid.HasErrors() {
+            t.Fatal(err)
+        }
+        key := ""
+        if valid.HasErrors() {
+            key = valid.Errors[0].Key
+        }
+        if key != test.key {
+            t.Errorf("Valid(%+v) error key = %s, want %s", test.f, key, test.key)
+        }
+    }
+
+    type invalid struct {
+        Name string `valid:"Keys(Alpha)"`
+    }
+    valid := Validation{}
+    valid.Valid(invalid{"a"})
+    if !valid.HasErrors() || valid.Errors[0].Key != "Name.Keys" {
+        t.Errorf("Keys of a string should be invalid, errors = %v", valid.Errors)
+    }
+}
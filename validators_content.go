@@ -0,0 +1,549 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "bytes"
+    "encoding/csv"
+    "encoding/json"
+    "encoding/xml"
+    "fmt"
+    "io"
+    "math"
+    "os"
+    "reflect"
+    "regexp"
+    "strings"
+    "sync"
+    "time"
+    "unicode/utf8"
+)
+
+// IsJSON check the string is a valid json value
+type IsJSON struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (i IsJSON) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    return ok && json.Valid([]byte(str))
+}
+
+// DefaultMessage return the default IsJSON error message
+func (i IsJSON) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["IsJSON"], fetchFieldName(i.Key))
+}
+
+// GetKey return the i.Key
+func (i IsJSON) GetKey() string {
+    return i.Key
+}
+
+// GetLimitValue return the limit value
+func (i IsJSON) GetLimitValue() interface{} {
+    return nil
+}
+
+// JSONObject check the string is a json object, such as {"name": "beego"}
+type JSONObject struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (j JSONObject) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    return ok && json.Valid([]byte(str)) && strings.HasPrefix(strings.TrimSpace(str), "{")
+}
+
+// DefaultMessage return the default JSONObject error message
+func (j JSONObject) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["JSONObject"], fetchFieldName(j.Key))
+}
+
+// GetKey return the j.Key
+func (j JSONObject) GetKey() string {
+    return j.Key
+}
+
+// GetLimitValue return the limit value
+func (j JSONObject) GetLimitValue() interface{} {
+    return nil
+}
+
+// isXML judge whether s is a well-formed xml document with exactly one root element
+func isXML(s string) bool {
+    decoder := xml.NewDecoder(strings.NewReader(s))
+    depth, roots := 0, 0
+    for {
+        token, err := decoder.Token()
+        if err == io.EOF {
+            return depth == 0 && roots == 1
+        }
+        if err != nil {
+            return false
+        }
+        switch t := token.(type) {
+        case xml.StartElement:
+            if depth == 0 {
+                roots++
+            }
+            depth++
+        case xml.EndElement:
+            depth--
+        case xml.CharData:
+            // only the white spaces are allowed outside the root element
+            if depth == 0 && len(bytes.TrimSpace(t)) > 0 {
+                return false
+            }
+        }
+    }
+}
+
+// IsXML check the string is a well-formed xml document
+type IsXML struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (i IsXML) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    return ok && isXML(str)
+}
+
+// DefaultMessage return the default IsXML error message
+func (i IsXML) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["IsXML"], fetchFieldName(i.Key))
+}
+
+// GetKey return the i.Key
+func (i IsXML) GetKey() string {
+    return i.Key
+}
+
+// GetLimitValue return the limit value
+func (i IsXML) GetLimitValue() interface{} {
+    return nil
+}
+
+// CSVRow check the string is a single csv record with Columns fields, any number of fields if Columns is 0
+type CSVRow struct {
+    Columns int
+    Key     string
+}
+
+// IsSatisfied judge whether obj is valid
+func (c CSVRow) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    if !ok || len(str) == 0 {
+        return false
+    }
+    reader := csv.NewReader(strings.NewReader(str))
+    reader.FieldsPerRecord = c.Columns
+    records, err := reader.ReadAll()
+    return err == nil && len(records) == 1
+}
+
+// DefaultMessage return the default CSVRow error message
+func (c CSVRow) DefaultMessage() string {
+    if c.Columns <= 0 {
+        return fmt.Sprintf(MessageTmpls["CSVRow"], fetchFieldName(c.Key))
+    }
+    return fmt.Sprintf(MessageTmpls["CSVRowColumns"], fetchFieldName(c.Key), c.Columns)
+}
+
+// GetKey return the c.Key
+func (c CSVRow) GetKey() string {
+    return c.Key
+}
+
+// GetLimitValue return the limit value
+func (c CSVRow) GetLimitValue() interface{} {
+    return c.Columns
+}
+
+// IsRegexp check the string is a regular expression which can be compiled by regexp.Compile
+type IsRegexp struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (i IsRegexp) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    if !ok {
+        return false
+    }
+    _, err := regexp.Compile(str)
+    return err == nil
+}
+
+// DefaultMessage return the default IsRegexp error message
+func (i IsRegexp) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["IsRegexp"], fetchFieldName(i.Key))
+}
+
+// GetKey return the i.Key
+func (i IsRegexp) GetKey() string {
+    return i.Key
+}
+
+// GetLimitValue return the limit value
+func (i IsRegexp) GetLimitValue() interface{} {
+    return nil
+}
+
+// jsonSchema a small subset of JSON Schema, the unknown keywords are not allowed
+type jsonSchema struct {
+    Schema      string `json:"$schema"`
+    ID          string `json:"$id"`
+    Title       string `json:"title"`
+    Description string `json:"description"`
+
+    // a type name or a list of type names: object, array, string, number, integer, boolean, null
+    Type                 interface{}            `json:"type"`
+    Enum                 []interface{}          `json:"enum"`
+    Properties           map[string]*jsonSchema `json:"properties"`
+    Required             []string               `json:"required"`
+    AdditionalProperties *bool                  `json:"additionalProperties"`
+    Items                *jsonSchema            `json:"items"`
+    MinItems             *int                   `json:"minItems"`
+    MaxItems             *int                   `json:"maxItems"`
+    MinLength            *int                   `json:"minLength"`
+    MaxLength            *int                   `json:"maxLength"`
+    Pattern              string                 `json:"pattern"`
+    Minimum              *float64               `json:"minimum"`
+    Maximum              *float64               `json:"maximum"`
+
+    types   []string
+    pattern *regexp.Regexp
+}
+
+// compile check the keywords and compile the pattern of the schema and its sub schemas
+func (s *jsonSchema) compile() error {
+    switch t := s.Type.(type) {
+    case nil:
+    case string:
+        s.types = []string{t}
+    case []interface{}:
+        for _, name := range t {
+            str, ok := name.(string)
+            if !ok {
+                return fmt.Errorf("invalid type %v", name)
+            }
+            s.types = append(s.types, str)
+        }
+    default:
+        return fmt.Errorf("invalid type %v", t)
+    }
+    for _, name := range s.types {
+        switch name {
+        case "object", "array", "string", "number", "integer", "boolean", "null":
+        default:
+            return fmt.Errorf("invalid type %s", name)
+        }
+    }
+    if len(s.Pattern) > 0 {
+        var err error
+        if s.pattern, err = regexp.Compile(s.Pattern); err != nil {
+            return err
+        }
+    }
+    for name, property := range s.Properties {
+        if property == nil {
+            return fmt.Errorf("invalid property %s", name)
+        }
+        if err := property.compile(); err != nil {
+            return fmt.Errorf("%s: %v", name, err)
+        }
+    }
+    if s.Items != nil {
+        if err := s.Items.compile(); err != nil {
+            return fmt.Errorf("items: %v", err)
+        }
+    }
+    return nil
+}
+
+// jsonTypeOf return the schema type name of the value decoded by encoding/json
+func jsonTypeOf(value interface{}) string {
+    switch v := value.(type) {
+    case map[string]interface{}:
+        return "object"
+    case []interface{}:
+        return "array"
+    case string:
+        return "string"
+    case float64:
+        if v == math.Trunc(v) {
+            return "integer"
+        }
+        return "number"
+    case bool:
+        return "boolean"
+    }
+    return "null"
+}
+
+// validate return the reason if the value doesn't match the schema, the path is the json path of the value
+func (s *jsonSchema) validate(path string, value interface{}) error {
+    if len(s.types) > 0 {
+        typ := jsonTypeOf(value)
+        matched := false
+        for _, name := range s.types {
+            if name == typ || (name == "number" && typ == "integer") {
+                matched = true
+                break
+            }
+        }
+        if !matched {
+            return fmt.Errorf("%s must be %s", path, strings.Join(s.types, " or "))
+        }
+    }
+    if len(s.Enum) > 0 {
+        matched := false
+        for _, v := range s.Enum {
+            if reflect.DeepEqual(v, value) {
+                matched = true
+                break
+            }
+        }
+        if !matched {
+            return fmt.Errorf("%s must be one of %v", path, s.Enum)
+        }
+    }
+
+    switch v := value.(type) {
+    case map[string]interface{}:
+        for _, name := range s.Required {
+            if _, ok := v[name]; !ok {
+                return fmt.Errorf("%s.%s is required", path, name)
+            }
+        }
+        for name, item := range v {
+            property, ok := s.Properties[name]
+            if !ok {
+                if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+                    return fmt.Errorf("%s.%s is not allowed", path, name)
+                }
+                continue
+            }
+            if err := property.validate(path + "." + name, item); err != nil {
+                return err
+            }
+        }
+    case []interface{}:
+        if s.MinItems != nil && len(v) < *s.MinItems {
+            return fmt.Errorf("%s must have at least %d items", path, *s.MinItems)
+        }
+        if s.MaxItems != nil && len(v) > *s.MaxItems {
+            return fmt.Errorf("%s must have at most %d items", path, *s.MaxItems)
+        }
+        if s.Items != nil {
+            for i, item := range v {
+                if err := s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+                    return err
+                }
+            }
+        }
+    case string:
+        length := utf8.RuneCountInString(v)
+        if s.MinLength != nil && length < *s.MinLength {
+            return fmt.Errorf("%s must be at least %d characters", path, *s.MinLength)
+        }
+        if s.MaxLength != nil && length > *s.MaxLength {
+            return fmt.Errorf("%s must be at most %d characters", path, *s.MaxLength)
+        }
+        if s.pattern != nil && !s.pattern.MatchString(v) {
+            return fmt.Errorf("%s must match %s", path, s.Pattern)
+        }
+    case float64:
+        if s.Minimum != nil && v < *s.Minimum {
+            return fmt.Errorf("%s must be at least %v", path, *s.Minimum)
+        }
+        if s.Maximum != nil && v > *s.Maximum {
+            return fmt.Errorf("%s must be at most %v", path, *s.Maximum)
+        }
+    }
+    return nil
+}
+
+// cachedJSONSchema the compiled schema and the state of the file when it was read
+type cachedJSONSchema struct {
+    schema  *jsonSchema
+    modTime time.Time
+    size    int64
+    checked time.Time
+}
+
+// the interval to check whether the schema file is modified, ReloadJSONSchema reload it at once
+var jsonSchemaCheckInterval = 5 * time.Second
+
+// key: the path of the schema file
+// value: the compiled schema
+var (
+    jsonSchemas   = make(map[string]cachedJSONSchema)
+    jsonSchemasMu sync.RWMutex
+)
+
+// readJSONSchema read and compile the schema file
+func readJSONSchema(path string) (cachedJSONSchema, error) {
+    info, err := os.Stat(path)
+    if err != nil {
+        return cachedJSONSchema{}, err
+    }
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return cachedJSONSchema{}, err
+    }
+    decoder := json.NewDecoder(bytes.NewReader(data))
+    decoder.DisallowUnknownFields()
+    schema := new(jsonSchema)
+    if err = decoder.Decode(schema); err != nil {
+        return cachedJSONSchema{}, fmt.Errorf("invalid json schema %s: %v", path, err)
+    }
+    if err = schema.compile(); err != nil {
+        return cachedJSONSchema{}, fmt.Errorf("invalid json schema %s: %v", path, err)
+    }
+    return cachedJSONSchema{schema, info.ModTime(), info.Size(), time.Now()}, nil
+}
+
+// loadJSONSchema return the cached schema, the file is checked at most once in jsonSchemaCheckInterval
+// and loaded again if it is modified. If the modified file is invalid, the last valid schema is
+// returned with the error, the schema is nil if the file has never been loaded
+func loadJSONSchema(path string) (*jsonSchema, error) {
+    jsonSchemasMu.RLock()
+    cached, ok := jsonSchemas[path]
+    jsonSchemasMu.RUnlock()
+    if ok && time.Since(cached.checked) < jsonSchemaCheckInterval {
+        return cached.schema, nil
+    }
+    if ok {
+        if info, err := os.Stat(path); err == nil && cached.modTime.Equal(info.ModTime()) && cached.size == info.Size() {
+            jsonSchemasMu.Lock()
+            cached.checked = time.Now()
+            jsonSchemas[path] = cached
+            jsonSchemasMu.Unlock()
+            return cached.schema, nil
+        }
+    }
+    return reloadJSONSchema(path)
+}
+
+// reloadJSONSchema read the schema file and cache it, the last valid schema is kept if the file is invalid
+func reloadJSONSchema(path string) (*jsonSchema, error) {
+    loaded, err := readJSONSchema(path)
+    jsonSchemasMu.Lock()
+    defer jsonSchemasMu.Unlock()
+    if err != nil {
+        cached, ok := jsonSchemas[path]
+        if ok {
+            // don't read the invalid file again until the next check
+            cached.checked = time.Now()
+            jsonSchemas[path] = cached
+        }
+        return cached.schema, err
+    }
+    jsonSchemas[path] = loaded
+    return loaded.schema, nil
+}
+
+// ReloadJSONSchema load the schema file used by JSONSchemaLite at once without waiting for the next check,
+// the last valid schema is kept if the file is invalid
+func ReloadJSONSchema(path string) error {
+    _, err := reloadJSONSchema(path)
+    return err
+}
+
+// ValidateJSONSchema check the json data against the schema file used by JSONSchemaLite,
+// and return the reason if it doesn't match
+func ValidateJSONSchema(path string, data []byte) error {
+    schema, err := loadJSONSchema(path)
+    if err != nil {
+        return err
+    }
+    var value interface{}
+    if err = json.Unmarshal(data, &value); err != nil {
+        return err
+    }
+    return schema.validate("$", value)
+}
+
+// JSONSchemaLite check the string is a json value which matches the local schema file,
+// only a small subset of JSON Schema is supported: type, enum, properties, required,
+// additionalProperties, items, minItems, maxItems, minLength, maxLength, pattern, minimum and maximum.
+// The schema file is loaded when the tag is compiled, the value is invalid if the file can't be loaded
+type JSONSchemaLite struct {
+    Path string
+    Key  string
+}
+
+// IsSatisfied judge whether obj is valid
+func (j JSONSchemaLite) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    if !ok {
+        return false
+    }
+    schema, _ := loadJSONSchema(j.Path)
+    if schema == nil {
+        return false
+    }
+    var value interface{}
+    return json.Unmarshal([]byte(str), &value) == nil && schema.validate("$", value) == nil
+}
+
+// DefaultMessage return the default JSONSchemaLite error message
+func (j JSONSchemaLite) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["JSONSchemaLite"], fetchFieldName(j.Key), j.Path)
+}
+
+// GetKey return the j.Key
+func (j JSONSchemaLite) GetKey() string {
+    return j.Key
+}
+
+// GetLimitValue return the limit value
+func (j JSONSchemaLite) GetLimitValue() interface{} {
+    return j.Path
+}
+
+// IsJSON Test that the obj is json if type is string
+func (v *Validation) IsJSON(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(IsJSON{key}, obj, errDesc)
+}
+
+// JSONObject Test that the obj is json object if type is string
+func (v *Validation) JSONObject(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(JSONObject{key}, obj, errDesc)
+}
+
+// IsXML Test that the obj is well-formed xml if type is string
+func (v *Validation) IsXML(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(IsXML{key}, obj, errDesc)
+}
+
+// CSVRow Test that the obj is a csv record with the number of columns if type is string
+func (v *Validation) CSVRow(obj interface{}, columns int, key string, errDesc string) *Result {
+    return v.apply(CSVRow{columns, key}, obj, errDesc)
+}
+
+// IsRegexp Test that the obj is regular expression if type is string
+func (v *Validation) IsRegexp(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(IsRegexp{key}, obj, errDesc)
+}
+
+// JSONSchemaLite Test that the obj is json matches the schema file if type is string
+func (v *Validation) JSONSchemaLite(obj interface{}, path string, key string, errDesc string) *Result {
+    return v.apply(JSONSchemaLite{path, key}, obj, errDesc)
+}
@@ -0,0 +1,220 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+)
+
+func TestContents(t *testing.T) {
+    tests := []struct {
+        name string
+        obj  interface{}
+        want bool
+    }{
+        {"IsJSON", `{"a": [1, 2]}`, true},
+        {"IsJSON", `"text"`, true},
+        {"IsJSON", `null`, true},
+        {"IsJSON", `{"a": }`, false},
+        {"IsJSON", ``, false},
+        {"IsJSON", 1, false},
+        {"JSONObject", ` {"name": "beego"}`, true},
+        {"JSONObject", `{}`, true},
+        {"JSONObject", `[1]`, false},
+        {"JSONObject", `{"name"}`, false},
+        {"IsXML", `<a><b>text</b></a>`, true},
+        {"IsXML", `<?xml version="1.0"?>
+<a/>
+`, true},
+        {"IsXML", `<a></b>`, false},
+        {"IsXML", `<a/><b/>`, false},
+        {"IsXML", `text<a/>`, false},
+        {"IsXML", `<a>`, false},
+        {"IsXML", ``, false},
+        {"IsRegexp", `^[a-z]+$`, true},
+        {"IsRegexp", ``, true},
+        {"IsRegexp", `[a-z`, false},
+        {"IsRegexp", `(?<name>a)`, true},
+        {"IsRegexp", `a**`, false},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        var r *Result
+        switch test.name {
+        case "IsJSON":
+            r = valid.IsJSON(test.obj, "data.IsJSON", "")
+        case "JSONObject":
+            r = valid.JSONObject(test.obj, "data.JSONObject", "")
+        case "IsXML":
+            r = valid.IsXML(test.obj, "data.IsXML", "")
+        case "IsRegexp":
+            r = valid.IsRegexp(test.obj, "data.IsRegexp", "")
+        }
+        if r.Ok != test.want {
+            t.Errorf("%s(%v) should be %v", test.name, test.obj, test.want)
+        }
+    }
+}
+
+func TestCSVRow(t *testing.T) {
+    tests := []struct {
+        obj     interface{}
+        columns int
+        want    bool
+    }{
+        {"a,b,c", 0, true},
+        {"a,b,c", 3, true},
+        {`a,"b,c",d`, 3, true},
+        {"a,b", 3, false},
+        {"a,b,c\nd,e,f", 0, false},
+        {`a,"b`, 0, false},
+        {"", 0, false},
+        {1, 0, false},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        if valid.CSVRow(test.obj, test.columns, "data.CSVRow", "").Ok != test.want {
+            t.Errorf("CSVRow(%v, %d) should be %v", test.obj, test.columns, test.want)
+        }
+    }
+}
+
+// writeSchema write the schema file and return its path
+func writeSchema(t *testing.T, path, data string) string {
+    if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+        t.Fatal(err)
+    }
+    return path
+}
+
+func TestJSONSchemaLite(t *testing.T) {
+    path := writeSchema(t, filepath.Join(t.TempDir(), "profile.json"), `{
+        "type": "object",
+        "required": ["name"],
+        "additionalProperties": false,
+        "properties": {
+            "name": {"type": "string", "minLength": 1, "maxLength": 4, "pattern": "^[a-z]+$"},
+            "age": {"type": "integer", "minimum": 0, "maximum": 150},
+            "role": {"enum": ["admin", "user"]},
+            "tags": {"type": "array", "maxItems": 2, "items": {"type": "string"}}
+        }
+    }`)
+    tests := []struct {
+        obj  interface{}
+        want bool
+    }{
+        {`{"name": "abc"}`, true},
+        {`{"name": "abc", "age": 18, "role": "user", "tags": ["a", "b"]}`, true},
+        {`{"age": 18}`, false},
+        {`{"name": ""}`, false},
+        {`{"name": "abcde"}`, false},
+        {`{"name": "ABC"}`, false},
+        {`{"name": "abc", "age": 1.5}`, false},
+        {`{"name": "abc", "age": -1}`, false},
+        {`{"name": "abc", "role": "root"}`, false},
+        {`{"name": "abc", "tags": ["a", "b", "c"]}`, false},
+        {`{"name": "abc", "tags": [1]}`, false},
+        {`{"name": "abc", "email": "a@b.c"}`, false},
+        {`["abc"]`, false},
+        {`{"name": `, false},
+        {1, false},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        if valid.JSONSchemaLite(test.obj, path, "data.JSONSchemaLite", "").Ok != test.want {
+            t.Errorf("JSONSchemaLite(%v) should be %v", test.obj, test.want)
+        }
+    }
+    if err := ValidateJSONSchema(path, []byte(`{"name": "abc", "age": 200}`)); err == nil || err.Error() != "$.age must be at most 150" {
+        t.Errorf("ValidateJSONSchema error = %v", err)
+    }
+}
+
+func TestJSONSchemaLiteTag(t *testing.T) {
+    dir := t.TempDir()
+    path := writeSchema(t, filepath.Join(dir, "name.json"), `{"type": "string"}`)
+    tests := []struct {
+        schema string
+        ok     bool
+    }{
+        {path, true},
+        {filepath.Join(dir, "missing.json"), false},
+        {writeSchema(t, filepath.Join(dir, "unknown.json"), `{"type": "string", "format": "email"}`), false},
+        {writeSchema(t, filepath.Join(dir, "type.json"), `{"type": "text"}`), false},
+        {writeSchema(t, filepath.Join(dir, "pattern.json"), `{"pattern": "[a-z"}`), false},
+    }
+    for _, test := range tests {
+        _, err := NewSchema(map[string]string{"data": "JSONSchemaLite(" + test.schema + ")"})
+        if (err == nil) != test.ok {
+            t.Errorf("the schema %s error = %v, want ok %v", test.schema, err, test.ok)
+        }
+    }
+
+    type profile struct {
+        Data string `valid:"JSONSchemaLite(testdata/missing.json)"`
+    }
+    if err := (&Validation{}).Valid(profile{`"abc"`}); err == nil {
+        t.Error("the missing schema should be reported by Valid")
+    }
+
+    // the missing file doesn't panic when the validator is applied directly
+    valid := Validation{}
+    if valid.JSONSchemaLite(`"abc"`, filepath.Join(dir, "missing.json"), "data.JSONSchemaLite", "").Ok {
+        t.Error("the value should be invalid if the schema can't be loaded")
+    }
+}
+
+func TestReloadJSONSchema(t *testing.T) {
+    interval := jsonSchemaCheckInterval
+    defer func() {
+        jsonSchemaCheckInterval = interval
+    }()
+    jsonSchemaCheckInterval = time.Hour
+
+    path := writeSchema(t, filepath.Join(t.TempDir(), "value.json"), `{"type": "string"}`)
+    valid := Validation{}
+    check := func(obj string, want bool) {
+        t.Helper()
+        if valid.JSONSchemaLite(obj, path, "data.JSONSchemaLite", "").Ok != want {
+            t.Errorf("JSONSchemaLite(%s) should be %v", obj, want)
+        }
+    }
+    check(`"abc"`, true)
+    check(`1`, false)
+
+    // the modified file isn't checked until the interval passes or it is reloaded
+    writeSchema(t, path, `{"type": "integer"}`)
+    check(`"abc"`, true)
+    if err := ReloadJSONSchema(path); err != nil {
+        t.Fatal(err)
+    }
+    check(`"abc"`, false)
+    check(`1`, true)
+
+    // the invalid file doesn't replace the last valid schema
+    writeSchema(t, path, `{"type": "int"}`)
+    if err := ReloadJSONSchema(path); err == nil {
+        t.Error("ReloadJSONSchema should return the error of the invalid file")
+    }
+    check(`1`, true)
+    jsonSchemaCheckInterval = 0
+    check(`1`, true)
+    writeSchema(t, path, `{"type": "boolean"}`)
+    check(`1`, false)
+    check(`true`, true)
+}
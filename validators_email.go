@@ -0,0 +1,285 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "bufio"
+    "fmt"
+    "net/mail"
+    "net/netip"
+    "os"
+    "strconv"
+    "strings"
+    "sync"
+
+    "golang.org/x/net/idna"
+)
+
+const (
+    // the max length of an email address by RFC 5321
+    emailMaxLength = 254
+    // the max length of the local part of an email address by RFC 5321
+    emailLocalMaxLength = 64
+)
+
+// key: domain list name
+// value: the domains, in lower case and ascii
+var (
+    emailDomains   = make(map[string]map[string]bool)
+    emailDomainsMu sync.RWMutex
+)
+
+// RegisterEmailDomains Register a named list of domains, which can be used by
+// Email(allow=name) or Email(deny=name) in the valid tag
+// If the name is same with exists list, it will replace the origin domains
+func RegisterEmailDomains(name string, domains ...string) error {
+    if len(strings.TrimSpace(name)) == 0 {
+        return fmt.Errorf("invalid domain list name: %s", name)
+    }
+    list := make(map[string]bool, len(domains))
+    for _, domain := range domains {
+        domain = strings.TrimSpace(domain)
+        if len(domain) == 0 {
+            continue
+        }
+        ascii, err := idna.Lookup.ToASCII(domain)
+        if err != nil {
+            return fmt.Errorf("invalid domain %s: %v", domain, err)
+        }
+        list[strings.ToLower(ascii)] = true
+    }
+    emailDomainsMu.Lock()
+    emailDomains[name] = list
+    emailDomainsMu.Unlock()
+    return nil
+}
+
+// LoadEmailDomains Register a named list of domains from a file, one domain per line,
+// the empty lines and the lines start with # are ignored, such as a list of disposable email domains
+func LoadEmailDomains(name, path string) error {
+    f, err := os.Open(path)
+    if err != nil {
+        return err
+    }
+    defer f.Close()
+
+    var domains []string
+    scanner := bufio.NewScanner(f)
+    for scanner.Scan() {
+        line := strings.TrimSpace(scanner.Text())
+        if len(line) == 0 || strings.HasPrefix(line, "#") {
+            continue
+        }
+        domains = append(domains, line)
+    }
+    if err = scanner.Err(); err != nil {
+        return err
+    }
+    return RegisterEmailDomains(name, domains...)
+}
+
+// inEmailDomains judge whether domain or its parent domain is in the named list
+func inEmailDomains(name, domain string) bool {
+    emailDomainsMu.RLock()
+    defer emailDomainsMu.RUnlock()
+    list := emailDomains[name]
+    for {
+        if list[domain] {
+            return true
+        }
+        index := strings.Index(domain, ".")
+        if index == -1 {
+            return false
+        }
+        domain = domain[index + 1:]
+    }
+}
+
+// Email check the string is an email address by RFC 5322, the local part can be quoted,
+// the domain can be an IDN domain or an address literal such as [192.0.2.1]
+// the embedded Match is kept for compatibility, its Regexp is used instead if it is set
+type Email struct {
+    Match
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (e Email) IsSatisfied(obj interface{}) bool {
+    if e.Regexp != nil {
+        return e.Match.IsSatisfied(obj)
+    }
+    return EmailWithOptions{Key: e.Key}.IsSatisfied(obj)
+}
+
+// DefaultMessage return the default Email error message
+func (e Email) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["Email"], fetchFieldName(e.Key))
+}
+
+// GetKey return the e.Key
+func (e Email) GetKey() string {
+    return e.Key
+}
+
+// GetLimitValue return the limit value
+func (e Email) GetLimitValue() interface{} {
+    return nil
+}
+
+// EmailWithOptions check the string is an email address as Email, with the options in the tag,
+// such as Email(name,max=100)
+type EmailWithOptions struct {
+    Options EmailOptions
+    Key     string
+}
+
+// EmailOptions the options of Email, the zero value is the default options
+// Options in the tag, such as Email(name,max=100,deny=disposable):
+//   name         allow display name, such as "John Doe <john@example.com>"
+//   max=N        the max length of the address, 254 by default
+//   allow=LIST   the domain must be in the list registered by RegisterEmailDomains or LoadEmailDomains
+//   deny=LIST    the domain must not be in the list registered by RegisterEmailDomains or LoadEmailDomains
+type EmailOptions struct {
+    Name      bool
+    MaxLength int
+    Allow     []string
+    Deny      []string
+}
+
+// ParseEmailOptions parse the options of Email in the tag, the domain lists must be registered before
+func ParseEmailOptions(options ...string) (opts EmailOptions, err error) {
+    for _, option := range options {
+        option = strings.TrimSpace(option)
+        name, value := option, ""
+        if index := strings.Index(option, "="); index >= 0 {
+            name, value = strings.TrimSpace(option[:index]), strings.TrimSpace(option[index + 1:])
+        }
+        switch name {
+        case "":
+        case "name":
+            opts.Name = true
+        case "max":
+            n, err := strconv.Atoi(value)
+            if err != nil || n <= 0 {
+                return opts, fmt.Errorf("invalid Email option: %s", option)
+            }
+            opts.MaxLength = n
+        case "allow", "deny":
+            emailDomainsMu.RLock()
+            _, ok := emailDomains[value]
+            emailDomainsMu.RUnlock()
+            if !ok {
+                return opts, fmt.Errorf("doesn't exist %s domain list", value)
+            }
+            if name == "allow" {
+                opts.Allow = append(opts.Allow, value)
+            } else {
+                opts.Deny = append(opts.Deny, value)
+            }
+        default:
+            return opts, fmt.Errorf("invalid Email option: %s", option)
+        }
+    }
+    return
+}
+
+// isEmailDomain judge whether domain is a host name with at least 2 labels or an address literal,
+// and return the ascii domain in lower case
+func isEmailDomain(domain string) (string, bool) {
+    if strings.HasPrefix(domain, "[") && strings.HasSuffix(domain, "]") {
+        literal := domain[1:len(domain) - 1]
+        if strings.HasPrefix(literal, "IPv6:") {
+            addr, err := netip.ParseAddr(literal[len("IPv6:"):])
+            return domain, err == nil && addr.Is6() && addr.Zone() == ""
+        }
+        addr, err := netip.ParseAddr(literal)
+        return domain, err == nil && addr.Is4()
+    }
+    ascii, err := idna.Lookup.ToASCII(domain)
+    if err != nil || !isHostname(ascii) || strings.HasSuffix(ascii, ".") || !strings.Contains(ascii, ".") {
+        return "", false
+    }
+    return strings.ToLower(ascii), true
+}
+
+// IsSatisfied judge whether obj is valid
+func (e EmailWithOptions) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    if !ok || len(str) == 0 || str != strings.TrimSpace(str) {
+        return false
+    }
+    opts := e.Options
+    maxLength := opts.MaxLength
+    if maxLength <= 0 {
+        maxLength = emailMaxLength
+    }
+
+    addr, err := mail.ParseAddress(str)
+    if err != nil {
+        return false
+    }
+    // only the address is allowed without the name option, such as <john@example.com> is invalid
+    if !opts.Name && (len(addr.Name) > 0 || strings.HasSuffix(str, ">")) {
+        return false
+    }
+    index := strings.LastIndex(addr.Address, "@")
+    if index == -1 {
+        return false
+    }
+    local, domain := addr.Address[:index], addr.Address[index + 1:]
+    if len(local) == 0 || len(local) > emailLocalMaxLength || len(addr.Address) > maxLength {
+        return false
+    }
+    if domain, ok = isEmailDomain(domain); !ok {
+        return false
+    }
+
+    for _, name := range opts.Deny {
+        if inEmailDomains(name, domain) {
+            return false
+        }
+    }
+    for _, name := range opts.Allow {
+        if inEmailDomains(name, domain) {
+            return true
+        }
+    }
+    return len(opts.Allow) == 0
+}
+
+// DefaultMessage return the default Email error message
+func (e EmailWithOptions) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["Email"], fetchFieldName(e.Key))
+}
+
+// GetKey return the e.Key
+func (e EmailWithOptions) GetKey() string {
+    return e.Key
+}
+
+// GetLimitValue return the options
+func (e EmailWithOptions) GetLimitValue() interface{} {
+    return e.Options
+}
+
+// Email Test that the obj is email address if type is string
+func (v *Validation) Email(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(Email{Key: key}, obj, errDesc)
+}
+
+// emailWithOptions the tag only variant of Email, such as Email(name,max=100), see EmailOptions
+func emailWithOptions(v *Validation, obj interface{}, options EmailOptions, key string, errDesc string) *Result {
+    return v.apply(EmailWithOptions{options, key}, obj, errDesc)
+}
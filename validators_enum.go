@@ -0,0 +1,240 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "fmt"
+    "reflect"
+    "strconv"
+    "strings"
+    "sync"
+)
+
+// key: enum name
+// value: the allowed values
+var (
+    enums   = make(map[string][]interface{})
+    enumsMu sync.RWMutex
+)
+
+// RegisterEnum Register a named set of values, which can be used by Enum(name) in the valid tag
+// the values can be strings or any numeric kinds, such as the constants of a go enum type
+// If the name is same with exists enum, it will replace the origin values
+func RegisterEnum(name string, values ...interface{}) error {
+    if len(strings.TrimSpace(name)) == 0 {
+        return fmt.Errorf("invalid enum name: %s", name)
+    }
+    if len(values) == 0 {
+        return fmt.Errorf("enum %s has no value", name)
+    }
+    for _, value := range values {
+        if _, ok := canonValue(reflect.ValueOf(value)); !ok {
+            return fmt.Errorf("enum %s does not support %T", name, value)
+        }
+    }
+    enumsMu.Lock()
+    enums[name] = values
+    enumsMu.Unlock()
+    return nil
+}
+
+func getEnum(name string) ([]interface{}, bool) {
+    enumsMu.RLock()
+    defer enumsMu.RUnlock()
+    values, ok := enums[name]
+    return values, ok
+}
+
+// canonValue convert string, bool and numeric value to a string which can be compared,
+// it use the underlying kind, so the String method of a go enum type is ignored
+func canonValue(v reflect.Value) (string, bool) {
+    switch v.Kind() {
+    case reflect.String:
+        return v.String(), true
+    case reflect.Bool:
+        return strconv.FormatBool(v.Bool()), true
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+        return strconv.FormatInt(v.Int(), 10), true
+    case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+        return strconv.FormatUint(v.Uint(), 10), true
+    case reflect.Float32, reflect.Float64:
+        return strconv.FormatFloat(v.Float(), 'g', -1, 64), true
+    }
+    return "", false
+}
+
+// inValues judge whether obj is one of the values
+// the string value is converted to the type of obj if obj is not a string
+func inValues(obj interface{}, values []interface{}, fold bool) bool {
+    ov := reflect.ValueOf(obj)
+    s, ok := canonValue(ov)
+    if !ok {
+        return false
+    }
+    isStr := ov.Kind() == reflect.String
+    for _, value := range values {
+        vv := reflect.ValueOf(value)
+        if vv.Kind() == reflect.String && !isStr {
+            param, err := parseParam(ov.Type(), strings.TrimSpace(vv.String()))
+            if err != nil {
+                continue
+            }
+            vv = reflect.ValueOf(param)
+        } else if (vv.Kind() == reflect.String) != isStr {
+            continue
+        }
+        c, ok := canonValue(vv)
+        if !ok {
+            continue
+        }
+        if c == s || (fold && isStr && strings.EqualFold(c, s)) {
+            return true
+        }
+    }
+    return false
+}
+
+func joinValues(values []interface{}) string {
+    strs := make([]string, len(values))
+    for i, value := range values {
+        strs[i] = fmt.Sprintf("%v", value)
+    }
+    return strings.Join(strs, ",")
+}
+
+func stringValues(strs []string) []interface{} {
+    values := make([]interface{}, len(strs))
+    for i, s := range strs {
+        values[i] = s
+    }
+    return values
+}
+
+// In Requires a string or number to be one of the given values
+type In struct {
+    Values []string
+    Fold   bool
+    Key    string
+}
+
+// IsSatisfied judge whether obj is valid
+func (i In) IsSatisfied(obj interface{}) bool {
+    return inValues(obj, stringValues(i.Values), i.Fold)
+}
+
+// DefaultMessage return the default In error message
+func (i In) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["In"], fetchFieldName(i.Key), strings.Join(i.Values, ","))
+}
+
+// GetKey return the i.Key
+func (i In) GetKey() string {
+    return i.Key
+}
+
+// GetLimitValue return the allowed values
+func (i In) GetLimitValue() interface{} {
+    return i.Values
+}
+
+// NotIn Requires a string or number not to be any of the given values
+type NotIn struct {
+    In
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (n NotIn) IsSatisfied(obj interface{}) bool {
+    if _, ok := canonValue(reflect.ValueOf(obj)); !ok {
+        return false
+    }
+    return !n.In.IsSatisfied(obj)
+}
+
+// DefaultMessage return the default NotIn error message
+func (n NotIn) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["NotIn"], fetchFieldName(n.Key), strings.Join(n.Values, ","))
+}
+
+// GetKey return the n.Key
+func (n NotIn) GetKey() string {
+    return n.Key
+}
+
+// GetLimitValue return the disallowed values
+func (n NotIn) GetLimitValue() interface{} {
+    return n.Values
+}
+
+// Enum Requires a string or number to be one of the values registered by RegisterEnum
+type Enum struct {
+    Name   string
+    Values []interface{}
+    Key    string
+}
+
+// IsSatisfied judge whether obj is valid
+func (e Enum) IsSatisfied(obj interface{}) bool {
+    return inValues(obj, e.Values, false)
+}
+
+// DefaultMessage return the default Enum error message
+func (e Enum) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["Enum"], fetchFieldName(e.Key), joinValues(e.Values))
+}
+
+// GetKey return the e.Key
+func (e Enum) GetKey() string {
+    return e.Key
+}
+
+// GetLimitValue return the allowed values
+func (e Enum) GetLimitValue() interface{} {
+    return e.Values
+}
+
+// In Test that the obj is one of the values if type is string or number
+func (v *Validation) In(obj interface{}, values []string, key string, errDesc string) *Result {
+    return v.apply(In{Values: values, Key: key}, obj, errDesc)
+}
+
+// OneOf Test that the obj is one of the values if type is string or number, same as In
+func (v *Validation) OneOf(obj interface{}, values []string, key string, errDesc string) *Result {
+    return v.apply(In{Values: values, Key: key}, obj, errDesc)
+}
+
+// InFold Test that the obj is one of the values if type is string or number, the string is case-insensitive
+func (v *Validation) InFold(obj interface{}, values []string, key string, errDesc string) *Result {
+    return v.apply(In{Values: values, Fold: true, Key: key}, obj, errDesc)
+}
+
+// NotIn Test that the obj is not any of the values if type is string or number
+func (v *Validation) NotIn(obj interface{}, values []string, key string, errDesc string) *Result {
+    return v.apply(NotIn{In{Values: values}, key}, obj, errDesc)
+}
+
+// NotInFold Test that the obj is not any of the values if type is string or number, the string is case-insensitive
+func (v *Validation) NotInFold(obj interface{}, values []string, key string, errDesc string) *Result {
+    return v.apply(NotIn{In{Values: values, Fold: true}, key}, obj, errDesc)
+}
+
+// Enum Test that the obj is one of the values registered by RegisterEnum(name)
+func (v *Validation) Enum(obj interface{}, name string, key string, errDesc string) *Result {
+    values, ok := getEnum(name)
+    if !ok {
+        panic(fmt.Errorf("doesn't exist %s enum", name))
+    }
+    return v.apply(Enum{name, values, key}, obj, errDesc)
+}
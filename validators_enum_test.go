@@ -0,0 +1,89 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "reflect"
+    "testing"
+)
+
+type enumStatus int
+
+func (s enumStatus) String() string {
+    return "status"
+}
+
+type enumOrder struct {
+    State    string       `valid:"In(new, paid ,done)"`
+    Channel  string       `valid:"InFold(web,app)"`
+    Level    int8         `valid:"In(1,2,3)"`
+    Rate     float64      `valid:"NotIn(0.5)"`
+    Status   enumStatus   `valid:"Enum(TestEnumStatus)"`
+    Statuses []enumStatus `valid:"Values(Enum(TestEnumStatus))"`
+}
+
+func TestEnum(t *testing.T) {
+    if err := RegisterEnum("TestEnumStatus", enumStatus(1), enumStatus(2)); err != nil {
+        t.Fatal(err)
+    }
+    tests := []struct {
+        o    enumOrder
+        want string
+    }{
+        {enumOrder{"paid", "APP", 2, 1, 2, []enumStatus{1, 2}}, ""},
+        {enumOrder{"x", "web", 1, 1, 1, nil}, "State.In"},
+        {enumOrder{"new", "x", 1, 1, 1, nil}, "Channel.InFold"},
+        {enumOrder{"new", "web", 4, 1, 1, nil}, "Level.In"},
+        {enumOrder{"new", "web", 1, 0.5, 1, nil}, "Rate.NotIn"},
+        // the underlying value is compared, the String method is ignored
+        {enumOrder{"new", "web", 1, 1, 3, nil}, "Status.Enum"},
+        {enumOrder{"new", "web", 1, 1, 1, []enumStatus{1, 3}}, "Statuses[1].Enum"},
+    }
+    for _, test := range tests {
+        valid := Validation{}
+        valid.Valid(test.o)
+        key := ""
+        if valid.HasErrors() {
+            key = valid.Errors[0].Key
+        }
+        if key != test.want {
+            t.Errorf("Valid(%v) error key = %s, want %s", test.o, key, test.want)
+        }
+    }
+}
+
+func TestEnumTag(t *testing.T) {
+    type unknown struct {
+        E int `valid:"Enum(TestEnumNope)"`
+    }
+    type nested struct {
+        E []int `valid:"Values(Or(Enum(TestEnumNope),Range(0,1)))"`
+    }
+    for _, obj := range []interface{}{unknown{}, nested{}} {
+        // the unknown enum is reported when the plan is compiled, before any value is validated
+        if _, err := getPlan(reflect.TypeOf(obj)); err == nil {
+            t.Errorf("the unknown enum of %T should be reported by the plan", obj)
+        }
+        if err := (&Validation{}).Valid(obj); err == nil {
+            t.Errorf("the unknown enum of %T should be reported by Valid", obj)
+        }
+    }
+    if err := RegisterEnum("", 1); err == nil {
+        t.Error("the empty enum name should be rejected")
+    }
+    if err := RegisterEnum("TestEnumInvalid", []int{1}); err == nil {
+        t.Error("the slice value should be rejected")
+    }
+}
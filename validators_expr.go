@@ -0,0 +1,526 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "fmt"
+    "math"
+    "reflect"
+    "strconv"
+    "strings"
+    "unicode"
+    "unicode/utf8"
+)
+
+// Expression a boolean expression over the fields of the struct being validated, such as
+// Type != 'promo' || Discount <= Amount * 0.5
+// it supports:
+//   the literals: numbers, strings in single or double quotes, true, false and nil
+//   the field references: Amount, the nested field Address.City, and $ for the value of the field itself
+//   the operators: + - * / % == != < <= > >= && || ! and the parentheses, + can also concatenate strings
+//   the functions: len(x) return the number of runes of a string, or the length of a slice, array or map
+// the expression is parsed once, the numbers are compared as float64, the nil pointers are nil
+type Expression struct {
+    src  string
+    root exprNode
+}
+
+// ParseExpression parse the expression, the surrounding double quotes are removed, such as "Amount > 0"
+func ParseExpression(s string) (e Expression, err error) {
+    s = strings.TrimSpace(s)
+    if len(s) >= 2 && s[0] == '"' && s[len(s) - 1] == '"' {
+        s = s[1:len(s) - 1]
+    }
+    e.src = s
+    p := &exprParser{src: s}
+    if err = p.next(); err != nil {
+        return
+    }
+    if e.root, err = p.parseBinary(1); err != nil {
+        return
+    }
+    if p.tok.kind != tokEOF {
+        err = fmt.Errorf("invalid expression %s: unexpected %s", s, p.tok.text)
+    }
+    return
+}
+
+// String return the source of the expression
+func (e Expression) String() string {
+    return e.src
+}
+
+// Eval evaluate the expression against the struct obj, self is the value referenced by $
+func (e Expression) Eval(obj interface{}, self interface{}) (result bool, err error) {
+    defer func() {
+        if r := recover(); r != nil {
+            err = fmt.Errorf("%v", r)
+        }
+    }()
+    v := reflect.ValueOf(obj)
+    for v.Kind() == reflect.Ptr && !v.IsNil() {
+        v = v.Elem()
+    }
+    return e.eval(v, self), nil
+}
+
+// eval evaluate the expression, it panics if the expression is invalid for the struct
+func (e Expression) eval(current reflect.Value, self interface{}) bool {
+    if e.root == nil {
+        panic(fmt.Errorf("empty expression"))
+    }
+    ctx := &exprContext{src: e.src, current: current, self: self}
+    result, ok := e.root.eval(ctx).(bool)
+    if !ok {
+        panic(fmt.Errorf("expression %s is not a boolean expression", e.src))
+    }
+    return result
+}
+
+type exprContext struct {
+    src     string
+    current reflect.Value
+    self    interface{}
+}
+
+func (c *exprContext) errorf(format string, args ...interface{}) {
+    panic(fmt.Errorf("expression %s: %s", c.src, fmt.Sprintf(format, args...)))
+}
+
+type exprNode interface {
+    eval(ctx *exprContext) interface{}
+}
+
+// the kinds of the tokens
+const (
+    tokEOF = iota
+    tokNumber
+    tokString
+    tokIdent
+    tokOp
+)
+
+type exprToken struct {
+    kind  int
+    text  string
+    value interface{}
+}
+
+type exprParser struct {
+    src string
+    pos int
+    tok exprToken
+}
+
+// the binary operators and their precedences
+var exprPrecedences = map[string]int{
+    "||": 1,
+    "&&": 2,
+    "==": 3, "!=": 3,
+    "<": 4, "<=": 4, ">": 4, ">=": 4,
+    "+": 5, "-": 5,
+    "*": 6, "/": 6, "%": 6,
+}
+
+func isIdentRune(r rune) bool {
+    return r == '_' || r == '$' || r == '.' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// next read the next token
+func (p *exprParser) next() error {
+    for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+        p.pos++
+    }
+    if p.pos >= len(p.src) {
+        p.tok = exprToken{kind: tokEOF, text: "end"}
+        return nil
+    }
+    start := p.pos
+    c := p.src[p.pos]
+    switch {
+    case '0' <= c && c <= '9':
+        for p.pos < len(p.src) && (('0' <= p.src[p.pos] && p.src[p.pos] <= '9') || p.src[p.pos] == '.') {
+            p.pos++
+        }
+        f, err := strconv.ParseFloat(p.src[start:p.pos], 64)
+        if err != nil {
+            return fmt.Errorf("invalid expression %s: invalid number %s", p.src, p.src[start:p.pos])
+        }
+        p.tok = exprToken{tokNumber, p.src[start:p.pos], f}
+    case c == '\'' || c == '"':
+        end := strings.IndexByte(p.src[start + 1:], c)
+        if end == -1 {
+            return fmt.Errorf("invalid expression %s: unterminated string", p.src)
+        }
+        p.pos = start + 1 + end + 1
+        p.tok = exprToken{tokString, p.src[start:p.pos], p.src[start + 1:p.pos - 1]}
+    default:
+        r, _ := utf8.DecodeRuneInString(p.src[p.pos:])
+        if isIdentRune(r) {
+            for p.pos < len(p.src) {
+                r, size := utf8.DecodeRuneInString(p.src[p.pos:])
+                if !isIdentRune(r) {
+                    break
+                }
+                p.pos += size
+            }
+            p.tok = exprToken{kind: tokIdent, text: p.src[start:p.pos]}
+            return nil
+        }
+        if p.pos + 1 < len(p.src) {
+            if op := p.src[p.pos:p.pos + 2]; exprPrecedences[op] > 0 {
+                p.pos += 2
+                p.tok = exprToken{kind: tokOp, text: op}
+                return nil
+            }
+        }
+        if !strings.ContainsRune("+-*/%<>!()", r) {
+            return fmt.Errorf("invalid expression %s: unexpected %c", p.src, r)
+        }
+        p.pos++
+        p.tok = exprToken{kind: tokOp, text: string(r)}
+    }
+    return nil
+}
+
+// parseBinary parse the binary expression whose operators' precedence are at least prec
+func (p *exprParser) parseBinary(prec int) (node exprNode, err error) {
+    if node, err = p.parseUnary(); err != nil {
+        return
+    }
+    for p.tok.kind == tokOp && exprPrecedences[p.tok.text] >= prec {
+        op := p.tok.text
+        if err = p.next(); err != nil {
+            return
+        }
+        var right exprNode
+        if right, err = p.parseBinary(exprPrecedences[op] + 1); err != nil {
+            return
+        }
+        node = &exprBinary{op, node, right}
+    }
+    return
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+    if p.tok.kind == tokOp && (p.tok.text == "!" || p.tok.text == "-") {
+        op := p.tok.text
+        if err := p.next(); err != nil {
+            return nil, err
+        }
+        x, err := p.parseUnary()
+        if err != nil {
+            return nil, err
+        }
+        return &exprUnary{op, x}, nil
+    }
+    return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (node exprNode, err error) {
+    tok := p.tok
+    switch tok.kind {
+    case tokNumber, tokString:
+        node = &exprLiteral{tok.value}
+    case tokIdent:
+        switch tok.text {
+        case "true":
+            node = &exprLiteral{true}
+        case "false":
+            node = &exprLiteral{false}
+        case "nil":
+            node = &exprLiteral{nil}
+        case "len":
+            if err = p.next(); err != nil {
+                return
+            }
+            if p.tok.text != "(" {
+                return nil, fmt.Errorf("invalid expression %s: len require (", p.src)
+            }
+            var x exprNode
+            if x, err = p.parseParen(); err != nil {
+                return
+            }
+            return &exprLen{x}, nil
+        default:
+            node = &exprField{strings.Split(tok.text, ".")}
+        }
+    case tokOp:
+        if tok.text == "(" {
+            return p.parseParen()
+        }
+        fallthrough
+    default:
+        return nil, fmt.Errorf("invalid expression %s: unexpected %s", p.src, tok.text)
+    }
+    err = p.next()
+    return
+}
+
+// parseParen parse the expression in the parentheses, the current token is (
+func (p *exprParser) parseParen() (node exprNode, err error) {
+    if err = p.next(); err != nil {
+        return
+    }
+    if node, err = p.parseBinary(1); err != nil {
+        return
+    }
+    if p.tok.text != ")" {
+        return nil, fmt.Errorf("invalid expression %s: missing )", p.src)
+    }
+    err = p.next()
+    return
+}
+
+// exprValue convert the reflect value to the value of the expression, the numbers are float64
+func exprValue(v reflect.Value) interface{} {
+    for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+        if v.IsNil() {
+            return nil
+        }
+        v = v.Elem()
+    }
+    switch v.Kind() {
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+        return float64(v.Int())
+    case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+        return float64(v.Uint())
+    case reflect.Float32, reflect.Float64:
+        return v.Float()
+    case reflect.String:
+        return v.String()
+    case reflect.Bool:
+        return v.Bool()
+    case reflect.Invalid:
+        return nil
+    }
+    if !v.CanInterface() {
+        return nil
+    }
+    return v.Interface()
+}
+
+type exprLiteral struct {
+    value interface{}
+}
+
+func (e *exprLiteral) eval(ctx *exprContext) interface{} {
+    return e.value
+}
+
+type exprField struct {
+    path []string
+}
+
+func (e *exprField) eval(ctx *exprContext) interface{} {
+    if e.path[0] == "$" {
+        if len(e.path) == 1 {
+            return exprValue(reflect.ValueOf(ctx.self))
+        }
+        ctx.errorf("$ can not have fields")
+    }
+    v := ctx.current
+    if !v.IsValid() {
+        ctx.errorf("the field %s can only be referenced in Valid", strings.Join(e.path, "."))
+    }
+    for _, name := range e.path {
+        for v.Kind() == reflect.Ptr {
+            if v.IsNil() {
+                return nil
+            }
+            v = v.Elem()
+        }
+        if v.Kind() != reflect.Struct {
+            ctx.errorf("%s is not a struct", strings.Join(e.path, "."))
+        }
+        if v = v.FieldByName(name); !v.IsValid() || !v.CanInterface() {
+            ctx.errorf("doesn't exist %s field", strings.Join(e.path, "."))
+        }
+    }
+    return exprValue(v)
+}
+
+type exprLen struct {
+    x exprNode
+}
+
+func (e *exprLen) eval(ctx *exprContext) interface{} {
+    switch x := e.x.eval(ctx).(type) {
+    case nil:
+        return float64(0)
+    case string:
+        return float64(utf8.RuneCountInString(x))
+    default:
+        v := reflect.ValueOf(x)
+        switch v.Kind() {
+        case reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+            return float64(v.Len())
+        }
+        ctx.errorf("invalid argument %v of len", x)
+    }
+    return nil
+}
+
+type exprUnary struct {
+    op string
+    x  exprNode
+}
+
+func (e *exprUnary) eval(ctx *exprContext) interface{} {
+    x := e.x.eval(ctx)
+    switch e.op {
+    case "!":
+        if b, ok := x.(bool); ok {
+            return !b
+        }
+    case "-":
+        if f, ok := x.(float64); ok {
+            return -f
+        }
+    }
+    ctx.errorf("invalid operation %s%v", e.op, x)
+    return nil
+}
+
+type exprBinary struct {
+    op   string
+    x, y exprNode
+}
+
+func (e *exprBinary) eval(ctx *exprContext) interface{} {
+    // short circuit
+    if e.op == "&&" || e.op == "||" {
+        x, ok := e.x.eval(ctx).(bool)
+        if !ok {
+            ctx.errorf("the operands of %s must be boolean", e.op)
+        }
+        if x == (e.op == "||") {
+            return x
+        }
+        y, ok := e.y.eval(ctx).(bool)
+        if !ok {
+            ctx.errorf("the operands of %s must be boolean", e.op)
+        }
+        return y
+    }
+
+    x, y := e.x.eval(ctx), e.y.eval(ctx)
+    if e.op == "==" || e.op == "!=" {
+        return exprEqual(ctx, x, y) == (e.op == "==")
+    }
+    switch a := x.(type) {
+    case float64:
+        b, ok := y.(float64)
+        if !ok {
+            break
+        }
+        switch e.op {
+        case "+":
+            return a + b
+        case "-":
+            return a - b
+        case "*":
+            return a * b
+        case "/":
+            if b == 0 {
+                ctx.errorf("division by zero")
+            }
+            return a / b
+        case "%":
+            if b == 0 {
+                ctx.errorf("division by zero")
+            }
+            return math.Mod(a, b)
+        case "<":
+            return a < b
+        case "<=":
+            return a <= b
+        case ">":
+            return a > b
+        case ">=":
+            return a >= b
+        }
+    case string:
+        b, ok := y.(string)
+        if !ok {
+            break
+        }
+        switch e.op {
+        case "+":
+            return a + b
+        case "<":
+            return a < b
+        case "<=":
+            return a <= b
+        case ">":
+            return a > b
+        case ">=":
+            return a >= b
+        }
+    }
+    ctx.errorf("invalid operation %v %s %v", x, e.op, y)
+    return nil
+}
+
+// exprEqual compare the values, nil is only equal to nil
+func exprEqual(ctx *exprContext, x, y interface{}) bool {
+    if x == nil || y == nil {
+        return x == nil && y == nil
+    }
+    switch a := x.(type) {
+    case float64, string, bool:
+        if reflect.TypeOf(x) != reflect.TypeOf(y) {
+            ctx.errorf("mismatched types %v == %v", x, y)
+        }
+        return a == y
+    }
+    return reflect.DeepEqual(x, y)
+}
+
+// Expr check the struct being validated matches the expression, such as Expr(Discount <= Amount * 0.5),
+// the obj is referenced by $, such as Expr($ >= MinPrice), see Expression
+type Expr struct {
+    Expression Expression
+    Key        string
+
+    // the struct being validated
+    current reflect.Value
+}
+
+// IsSatisfied judge whether obj is valid
+func (e Expr) IsSatisfied(obj interface{}) bool {
+    return e.Expression.eval(e.current, obj)
+}
+
+// DefaultMessage return the default Expr error message
+func (e Expr) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["Expr"], fetchFieldName(e.Key), e.Expression)
+}
+
+// GetKey return the e.Key
+func (e Expr) GetKey() string {
+    return e.Key
+}
+
+// GetLimitValue return the expression
+func (e Expr) GetLimitValue() interface{} {
+    return e.Expression.src
+}
+
+// Expr Test that the struct being validated matches the expression, it can only be used by Valid
+// if the expression references the fields
+func (v *Validation) Expr(obj interface{}, expr Expression, key string, errDesc string) *Result {
+    return v.apply(Expr{expr, key, v.current}, obj, errDesc)
+}
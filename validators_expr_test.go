@@ -0,0 +1,140 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "testing"
+)
+
+type exprAddress struct {
+    City string
+}
+
+type exprOrder struct {
+    Type     string
+    Amount   float64
+    Discount int
+    Tags     []string
+    Address  *exprAddress
+}
+
+func TestParseExpression(t *testing.T) {
+    tests := []struct {
+        expr string
+        ok   bool
+    }{
+        {"Amount > 0", true},
+        {`"Amount > 0"`, true},
+        {"Type != 'promo' || Discount <= Amount * 0.5", true},
+        {"!(len(Tags) == 0) && Address.City != \"\"", true},
+        {"$ >= 1", true},
+        {"Amount >", false},
+        {"(Amount > 0", false},
+        {"Amount > 0)", false},
+        {"Type == 'promo", false},
+        {"Amount # 1", false},
+        {"", false},
+    }
+    for _, test := range tests {
+        if _, err := ParseExpression(test.expr); (err == nil) != test.ok {
+            t.Errorf("ParseExpression(%q) error = %v, want ok %v", test.expr, err, test.ok)
+        }
+    }
+}
+
+func TestExpressionEval(t *testing.T) {
+    order := exprOrder{"promo", 100, 50, []string{"a", "b"}, &exprAddress{"上海"}}
+    tests := []struct {
+        expr string
+        self interface{}
+        want bool
+        ok   bool
+    }{
+        // the precedences and the arithmetic
+        {"1 + 2 * 3 == 7", nil, true, true},
+        {"(1 + 2) * 3 == 9", nil, true, true},
+        {"7 % 4 == 3 && -2 < 0", nil, true, true},
+        {"10 / 4 == 2.5", nil, true, true},
+        {"true || false && false", nil, true, true},
+        {"!true == false", nil, true, true},
+        // the fields
+        {"Type != 'promo' || Discount <= Amount * 0.5", nil, true, true},
+        {"Discount > Amount", nil, false, true},
+        {"Type + '!' == 'promo!'", nil, true, true},
+        {"len(Tags) == 2 && len(Type) == 5", nil, true, true},
+        {"Address != nil && Address.City == '上海' && len(Address.City) == 2", nil, true, true},
+        // $ is the value of the field itself
+        {"$ >= Discount", 50, true, true},
+        {"$ >= Discount", 49, false, true},
+        {"$ == nil", nil, true, true},
+        // the errors
+        {"Nope > 1", nil, false, false},
+        {"Type + 1 > 1", nil, false, false},
+        {"Amount", nil, false, false},
+        {"Type > 1", nil, false, false},
+    }
+    for _, test := range tests {
+        e, err := ParseExpression(test.expr)
+        if err != nil {
+            t.Errorf("ParseExpression(%q) error = %v", test.expr, err)
+            continue
+        }
+        got, err := e.Eval(&order, test.self)
+        if (err == nil) != test.ok {
+            t.Errorf("Eval(%q) error = %v, want ok %v", test.expr, err, test.ok)
+            continue
+        }
+        if got != test.want {
+            t.Errorf("Eval(%q) = %v, want %v", test.expr, got, test.want)
+        }
+    }
+}
+
+func TestExprTag(t *testing.T) {
+    type order struct {
+        Type     string
+        Amount   float64
+        Discount int      `valid:"Expr(Type != 'a,b;(c)' || Discount <= Amount * 0.5)"`
+        Tags     []string `valid:"Expr(len(Tags) >= 1 && len(Tags) < 3)"`
+    }
+    tests := []struct {
+        o    order
+        want string
+    }{
+        {order{"a,b;(c)", 100, 50, []string{"x"}}, ""},
+        {order{"other", 100, 80, []string{"x"}}, ""},
+        {order{"a,b;(c)", 100, 51, []string{"x"}}, "Discount.Expr"},
+        {order{"a,b;(c)", 100, 50, nil}, "Tags.Expr"},
+        {order{"a,b;(c)", 100, 50, []string{"x", "y", "z"}}, "Tags.Expr"},
+    }
+    for _, test := range tests {
+        valid := Validation{}
+        valid.Valid(test.o)
+        key := ""
+        if valid.HasErrors() {
+            key = valid.Errors[0].Key
+        }
+        if key != test.want {
+            t.Errorf("Valid(%v) error key = %s, want %s", test.o, key, test.want)
+        }
+    }
+
+    type invalid struct {
+        A int `valid:"Expr(A > )"`
+    }
+    if err := (&Validation{}).Valid(invalid{}); err == nil {
+        t.Error("the invalid expression should be reported by Valid")
+    }
+}
@@ -0,0 +1,327 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "fmt"
+    "regexp"
+    "strings"
+)
+
+// the card brands detected by CardBrand
+const (
+    CardVisa       = "visa"
+    CardMastercard = "mastercard"
+    CardUnionPay   = "unionpay"
+    CardAmex       = "amex"
+)
+
+// the card number lengths of the brands
+var cardBrandLengths = map[string][2]int{
+    CardVisa:       {13, 19},
+    CardMastercard: {16, 16},
+    CardUnionPay:   {16, 19},
+    CardAmex:       {15, 15},
+}
+
+// CardBrands the allowed brands of CreditCard, such as visa and unionpay of CreditCard(visa,unionpay)
+type CardBrands []string
+
+// ParseCardBrands check the brands are detected by CardBrand, the brands are case-insensitive
+func ParseCardBrands(brands ...string) (CardBrands, error) {
+    var cb CardBrands
+    for _, brand := range brands {
+        brand = strings.ToLower(strings.TrimSpace(brand))
+        if len(brand) == 0 {
+            continue
+        }
+        if _, ok := cardBrandLengths[brand]; !ok {
+            return nil, fmt.Errorf("doesn't exist %s card brand", brand)
+        }
+        cb = append(cb, brand)
+    }
+    return cb, nil
+}
+
+// CardBrand detect the brand of the card number by the leading digits, return "" if unknown
+func CardBrand(number string) string {
+    if len(number) < 4 || !isDigits(number[:4]) {
+        return ""
+    }
+    bin := int(number[0] - '0') * 1000 + int(number[1] - '0') * 100 + int(number[2] - '0') * 10 + int(number[3] - '0')
+    switch {
+    case number[0] == '4':
+        return CardVisa
+    case 5100 <= bin && bin <= 5599, 2221 <= bin && bin <= 2720:
+        return CardMastercard
+    case bin / 100 == 34, bin / 100 == 37:
+        return CardAmex
+    case bin / 100 == 62, bin / 100 == 81:
+        return CardUnionPay
+    }
+    return ""
+}
+
+// the length of the IBAN of each country
+var ibanLengths = map[string]int{
+    "AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22, "BH": 22,
+    "BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24, "DE": 22, "DK": 18, "DO": 28,
+    "EE": 20, "EG": 29, "ES": 24, "FI": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23,
+    "GL": 18, "GR": 27, "GT": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26,
+    "IT": 27, "JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20,
+    "LV": 21, "MC": 27, "MD": 24, "ME": 22, "MK": 19, "MR": 27, "MT": 31, "MU": 30, "NL": 18,
+    "NO": 15, "PK": 24, "PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "SA": 24,
+    "SC": 31, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "ST": 25, "SV": 28, "TL": 23, "TN": 24,
+    "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20,
+}
+
+// the active currency codes of ISO 4217
+var currencyCodes = map[string]bool{
+    "AED": true, "AFN": true, "ALL": true, "AMD": true, "ANG": true, "AOA": true, "ARS": true,
+    "AUD": true, "AWG": true, "AZN": true, "BAM": true, "BBD": true, "BDT": true, "BGN": true,
+    "BHD": true, "BIF": true, "BMD": true, "BND": true, "BOB": true, "BRL": true, "BSD": true,
+    "BTN": true, "BWP": true, "BYN": true, "BZD": true, "CAD": true, "CDF": true, "CHF": true,
+    "CLP": true, "CNY": true, "COP": true, "CRC": true, "CUP": true, "CVE": true, "CZK": true,
+    "DJF": true, "DKK": true, "DOP": true, "DZD": true, "EGP": true, "ERN": true, "ETB": true,
+    "EUR": true, "FJD": true, "FKP": true, "GBP": true, "GEL": true, "GHS": true, "GIP": true,
+    "GMD": true, "GNF": true, "GTQ": true, "GYD": true, "HKD": true, "HNL": true, "HTG": true,
+    "HUF": true, "IDR": true, "ILS": true, "INR": true, "IQD": true, "IRR": true, "ISK": true,
+    "JMD": true, "JOD": true, "JPY": true, "KES": true, "KGS": true, "KHR": true, "KMF": true,
+    "KPW": true, "KRW": true, "KWD": true, "KYD": true, "KZT": true, "LAK": true, "LBP": true,
+    "LKR": true, "LRD": true, "LSL": true, "LYD": true, "MAD": true, "MDL": true, "MGA": true,
+    "MKD": true, "MMK": true, "MNT": true, "MOP": true, "MRU": true, "MUR": true, "MVR": true,
+    "MWK": true, "MXN": true, "MYR": true, "MZN": true, "NAD": true, "NGN": true, "NIO": true,
+    "NOK": true, "NPR": true, "NZD": true, "OMR": true, "PAB": true, "PEN": true, "PGK": true,
+    "PHP": true, "PKR": true, "PLN": true, "PYG": true, "QAR": true, "RON": true, "RSD": true,
+    "RUB": true, "RWF": true, "SAR": true, "SBD": true, "SCR": true, "SDG": true, "SEK": true,
+    "SGD": true, "SHP": true, "SLE": true, "SOS": true, "SRD": true, "SSP": true, "STN": true,
+    "SVC": true, "SYP": true, "SZL": true, "THB": true, "TJS": true, "TMT": true, "TND": true,
+    "TOP": true, "TRY": true, "TTD": true, "TWD": true, "TZS": true, "UAH": true, "UGX": true,
+    "USD": true, "UYU": true, "UZS": true, "VES": true, "VND": true, "VUV": true, "WST": true,
+    "XAF": true, "XCD": true, "XOF": true, "XPF": true, "YER": true, "ZAR": true, "ZMW": true,
+    "ZWL": true,
+}
+
+var bicPattern = regexp.MustCompile("^[A-Z]{4}[A-Z]{2}[A-Z0-9]{2}([A-Z0-9]{3})?$")
+
+// isIBAN judge whether s is an IBAN in electronic format (without spaces) or in print format
+// (groups of 4 characters separated by spaces), check the length of the country and mod-97
+func isIBAN(s string) bool {
+    s = strings.ReplaceAll(s, " ", "")
+    if len(s) < 5 {
+        return false
+    }
+    if length, ok := ibanLengths[s[:2]]; !ok || length != len(s) || !isDigits(s[2:4]) {
+        return false
+    }
+    // move the first 4 characters to the end, and convert the letters to 10-35
+    rem := 0
+    for _, c := range s[4:] + s[:4] {
+        switch {
+        case '0' <= c && c <= '9':
+            rem = (rem * 10 + int(c - '0')) % 97
+        case 'A' <= c && c <= 'Z':
+            rem = (rem * 100 + int(c - 'A' + 10)) % 97
+        default:
+            return false
+        }
+    }
+    return rem == 1
+}
+
+// CreditCard check the string is a credit card number, which pass the luhn checksum,
+// the brand must be one of Brands if it is not empty, see CardBrand
+type CreditCard struct {
+    Brands CardBrands
+    Key    string
+}
+
+// IsSatisfied judge whether obj is valid
+func (c CreditCard) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    if !ok || !luhn(str) {
+        return false
+    }
+    brand := CardBrand(str)
+    if length, ok := cardBrandLengths[brand]; !ok || len(str) < length[0] || len(str) > length[1] {
+        return false
+    }
+    if len(c.Brands) == 0 {
+        return true
+    }
+    for _, b := range c.Brands {
+        if strings.EqualFold(b, brand) {
+            return true
+        }
+    }
+    return false
+}
+
+// DefaultMessage return the default CreditCard error message
+func (c CreditCard) DefaultMessage() string {
+    if len(c.Brands) == 0 {
+        return fmt.Sprintf(MessageTmpls["CreditCard"], fetchFieldName(c.Key))
+    }
+    return fmt.Sprintf(MessageTmpls["CreditCardBrand"], fetchFieldName(c.Key), strings.Join(c.Brands, ","))
+}
+
+// GetKey return the c.Key
+func (c CreditCard) GetKey() string {
+    return c.Key
+}
+
+// GetLimitValue return the allowed brands
+func (c CreditCard) GetLimitValue() interface{} {
+    return c.Brands
+}
+
+// IBAN check the string is an international bank account number
+type IBAN struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (i IBAN) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    return ok && isIBAN(str)
+}
+
+// DefaultMessage return the default IBAN error message
+func (i IBAN) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["IBAN"], fetchFieldName(i.Key))
+}
+
+// GetKey return the i.Key
+func (i IBAN) GetKey() string {
+    return i.Key
+}
+
+// GetLimitValue return the limit value
+func (i IBAN) GetLimitValue() interface{} {
+    return nil
+}
+
+// BIC check the string is a BIC/SWIFT code of 8 or 11 characters, such as DEUTDEFF500
+type BIC struct {
+    Match
+    Key string
+}
+
+// DefaultMessage return the default BIC error message
+func (b BIC) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["BIC"], fetchFieldName(b.Key))
+}
+
+// GetKey return the b.Key
+func (b BIC) GetKey() string {
+    return b.Key
+}
+
+// GetLimitValue return the limit value
+func (b BIC) GetLimitValue() interface{} {
+    return nil
+}
+
+// Currency check the string is an ISO 4217 currency code, such as CNY
+type Currency struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (c Currency) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    return ok && currencyCodes[str]
+}
+
+// DefaultMessage return the default Currency error message
+func (c Currency) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["Currency"], fetchFieldName(c.Key))
+}
+
+// GetKey return the c.Key
+func (c Currency) GetKey() string {
+    return c.Key
+}
+
+// GetLimitValue return the limit value
+func (c Currency) GetLimitValue() interface{} {
+    return nil
+}
+
+// Money check the string is a non-negative decimal with at most Precision digits after the point,
+// such as 12.34 for Money(2)
+type Money struct {
+    Precision int
+    Key       string
+}
+
+// IsSatisfied judge whether obj is valid
+func (m Money) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    if !ok {
+        return false
+    }
+    integer, fraction := str, ""
+    if index := strings.Index(str, "."); index >= 0 {
+        integer, fraction = str[:index], str[index + 1:]
+        if len(fraction) == 0 || !isDigits(fraction) {
+            return false
+        }
+    }
+    if !isDigits(integer) || (len(integer) > 1 && integer[0] == '0') {
+        return false
+    }
+    return len(fraction) <= m.Precision
+}
+
+// DefaultMessage return the default Money error message
+func (m Money) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["Money"], fetchFieldName(m.Key), m.Precision)
+}
+
+// GetKey return the m.Key
+func (m Money) GetKey() string {
+    return m.Key
+}
+
+// GetLimitValue return the limit value
+func (m Money) GetLimitValue() interface{} {
+    return m.Precision
+}
+
+// CreditCard Test that the obj is credit card number if type is string, the brand must be one of brands if it is not empty
+func (v *Validation) CreditCard(obj interface{}, brands CardBrands, key string, errDesc string) *Result {
+    return v.apply(CreditCard{brands, key}, obj, errDesc)
+}
+
+// IBAN Test that the obj is international bank account number if type is string
+func (v *Validation) IBAN(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(IBAN{key}, obj, errDesc)
+}
+
+// BIC Test that the obj is BIC/SWIFT code if type is string
+func (v *Validation) BIC(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(BIC{Match{Regexp: bicPattern}, key}, obj, errDesc)
+}
+
+// Currency Test that the obj is ISO 4217 currency code if type is string
+func (v *Validation) Currency(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(Currency{key}, obj, errDesc)
+}
+
+// Money Test that the obj is non-negative decimal with at most precision digits after the point if type is string
+func (v *Validation) Money(obj interface{}, precision int, key string, errDesc string) *Result {
+    return v.apply(Money{precision, key}, obj, errDesc)
+}
@@ -0,0 +1,194 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "testing"
+)
+
+func TestCardBrand(t *testing.T) {
+    tests := []struct {
+        number string
+        brand  string
+    }{
+        {"4111111111111111", CardVisa},
+        {"4222222222222", CardVisa},
+        {"5555555555554444", CardMastercard},
+        {"5105105105105100", CardMastercard},
+        {"2221000000000009", CardMastercard},
+        {"378282246310005", CardAmex},
+        {"371449635398431", CardAmex},
+        {"6225880137706868", CardUnionPay},
+        {"8171999927660000", CardUnionPay},
+        // Discover and JCB are not detected
+        {"6011111111111117", ""},
+        {"3530111333300000", ""},
+        {"411", ""},
+        {"abcd1111", ""},
+    }
+    for _, test := range tests {
+        if brand := CardBrand(test.number); brand != test.brand {
+            t.Errorf("CardBrand(%s) = %s, want %s", test.number, brand, test.brand)
+        }
+    }
+}
+
+func TestCreditCard(t *testing.T) {
+    tests := []struct {
+        obj    interface{}
+        brands []string
+        want   bool
+    }{
+        {"4111111111111111", nil, true},
+        {"4222222222222", nil, true},
+        {"5555555555554444", nil, true},
+        {"378282246310005", nil, true},
+        {"62123456789000003", nil, true},
+        // the luhn checksum
+        {"4111111111111112", nil, false},
+        {"5555555555554445", nil, false},
+        // the length of the brand
+        {"3782822463100050", nil, false},
+        {"555555555555444", nil, false},
+        // the unknown brand
+        {"6011111111111117", nil, false},
+        {"4111 1111 1111 1111", nil, false},
+        {"", nil, false},
+        {4111111111111111, nil, false},
+        {"4111111111111111", []string{"mastercard"}, false},
+        {"5555555555554444", []string{"mastercard", "visa"}, true},
+        {"6225880137706868", []string{"UnionPay"}, true},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        brands, err := ParseCardBrands(test.brands...)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if valid.CreditCard(test.obj, brands, "card.CreditCard", "").Ok != test.want {
+            t.Errorf("CreditCard(%v, %v) should be %v", test.obj, test.brands, test.want)
+        }
+    }
+}
+
+func TestCreditCardTag(t *testing.T) {
+    if _, err := ParseCardBrands("visa", " AMEX "); err != nil {
+        t.Errorf("ParseCardBrands error = %v", err)
+    }
+    type invalid struct {
+        Card string `valid:"CreditCard(viza)"`
+    }
+    if err := (&Validation{}).Valid(invalid{"4111111111111111"}); err == nil || err.Error() != "doesn't exist viza card brand" {
+        t.Errorf("the unknown brand should be reported by Valid, error = %v", err)
+    }
+}
+
+func TestIBAN(t *testing.T) {
+    tests := []struct {
+        obj  interface{}
+        want bool
+    }{
+        {"GB82WEST12345698765432", true},
+        {"GB82 WEST 1234 5698 7654 32", true},
+        {"DE89370400440532013000", true},
+        {"FR1420041010050500013M02606", true},
+        {"NL91ABNA0417164300", true},
+        {"BE68539007547034", true},
+        {"NO9386011117947", true},
+        {"MT84MALT011000012345MTLCAST001S", true},
+        // the length of the country
+        {"GB82WEST123456987654320", false},
+        {"GB82WEST1234569876543", false},
+        {"NO938601111794", false},
+        {"DE8937040044053201300", false},
+        // the check digits
+        {"GB83WEST12345698765432", false},
+        {"DE89370400440532013001", false},
+        // the unknown country and the lower case
+        {"XX82WEST12345698765432", false},
+        {"gb82west12345698765432", false},
+        {"GB", false},
+        {"", false},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        if valid.IBAN(test.obj, "bank.IBAN", "").Ok != test.want {
+            t.Errorf("IBAN(%v) should be %v", test.obj, test.want)
+        }
+    }
+}
+
+func TestBIC(t *testing.T) {
+    tests := []struct {
+        obj  interface{}
+        want bool
+    }{
+        {"DEUTDEFF", true},
+        {"DEUTDEFF500", true},
+        {"BKCHCNBJ", true},
+        {"deutdeff", false},
+        {"DEUTDEF", false},
+        {"DEUTDEFF50", false},
+        {"DEU1DEFF", false},
+        {"DEUTD1FF", false},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        if valid.BIC(test.obj, "bank.BIC", "").Ok != test.want {
+            t.Errorf("BIC(%v) should be %v", test.obj, test.want)
+        }
+    }
+}
+
+func TestMoney(t *testing.T) {
+    tests := []struct {
+        obj       interface{}
+        precision int
+        want      bool
+    }{
+        {"0", 2, true},
+        {"12", 2, true},
+        {"12.3", 2, true},
+        {"12.34", 2, true},
+        {"0.01", 2, true},
+        {"12.345", 2, false},
+        {"12", 0, true},
+        {"12.3", 0, false},
+        {"12.", 2, false},
+        {".5", 2, false},
+        {"012", 2, false},
+        {"-1", 2, false},
+        {"+1", 2, false},
+        {"1,000", 2, false},
+        {"1e3", 2, false},
+        {"", 2, false},
+        {12.34, 2, false},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        if valid.Money(test.obj, test.precision, "price.Money", "").Ok != test.want {
+            t.Errorf("Money(%v, %d) should be %v", test.obj, test.precision, test.want)
+        }
+    }
+}
+
+func TestCurrency(t *testing.T) {
+    valid := Validation{}
+    for obj, want := range map[string]bool{"CNY": true, "USD": true, "EUR": true, "cny": false, "XXX": false, "RMB": false} {
+        if valid.Currency(obj, "price.Currency", "").Ok != want {
+            t.Errorf("Currency(%s) should be %v", obj, want)
+        }
+    }
+}
@@ -0,0 +1,444 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "fmt"
+    "regexp"
+    "strconv"
+    "strings"
+)
+
+var (
+    uuidPattern        = regexp.MustCompile("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$")
+    ulidPattern        = regexp.MustCompile("^[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}$")
+    semverPattern      = regexp.MustCompile("^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)" +
+        "(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?" +
+        "(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$")
+    hexadecimalPattern = regexp.MustCompile("^(0[xX])?[0-9a-fA-F]+$")
+    hexColorPattern    = regexp.MustCompile("^#(?:[0-9a-fA-F]{3,4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$")
+    objectIDPattern    = regexp.MustCompile("^[0-9a-fA-F]{24}$")
+)
+
+// parseColorArgs parse the arguments of the css function such as rgb(255, 0, 0) or rgba(255, 0, 0, 0.5),
+// the color values can be 0-255 or percentages, the alpha value can be 0-1 or a percentage
+func parseColorArgs(s, name string, alpha bool) bool {
+    if !strings.HasPrefix(s, name + "(") || !strings.HasSuffix(s, ")") {
+        return false
+    }
+    args := strings.Split(s[len(name) + 1:len(s) - 1], ",")
+    n := 3
+    if alpha {
+        n = 4
+    }
+    if len(args) != n {
+        return false
+    }
+    percent := strings.HasSuffix(strings.TrimSpace(args[0]), "%")
+    for i, arg := range args {
+        arg = strings.TrimSpace(arg)
+        if i == 3 {
+            if strings.HasSuffix(arg, "%") {
+                f, err := strconv.ParseFloat(arg[:len(arg) - 1], 64)
+                return err == nil && 0 <= f && f <= 100
+            }
+            f, err := strconv.ParseFloat(arg, 64)
+            return err == nil && 0 <= f && f <= 1
+        }
+        // the color values must be all percentages or all integers
+        if strings.HasSuffix(arg, "%") != percent {
+            return false
+        }
+        if percent {
+            f, err := strconv.ParseFloat(arg[:len(arg) - 1], 64)
+            if err != nil || f < 0 || f > 100 {
+                return false
+            }
+        } else if n, err := strconv.Atoi(arg); err != nil || n < 0 || n > 255 {
+            return false
+        }
+    }
+    return true
+}
+
+// stripISBN remove the hyphens and spaces in the ISBN
+func stripISBN(s string) string {
+    return strings.Map(func(r rune) rune {
+        if r == '-' || r == ' ' {
+            return -1
+        }
+        return r
+    }, s)
+}
+
+func isISBN10(s string) bool {
+    s = stripISBN(s)
+    if len(s) != 10 || !isDigits(s[:9]) {
+        return false
+    }
+    sum := 0
+    for i := 0; i < 9; i++ {
+        sum += int(s[i] - '0') * (10 - i)
+    }
+    switch {
+    case s[9] == 'X' || s[9] == 'x':
+        sum += 10
+    case '0' <= s[9] && s[9] <= '9':
+        sum += int(s[9] - '0')
+    default:
+        return false
+    }
+    return sum % 11 == 0
+}
+
+func isISBN13(s string) bool {
+    s = stripISBN(s)
+    if len(s) != 13 || !isDigits(s) || (!strings.HasPrefix(s, "978") && !strings.HasPrefix(s, "979")) {
+        return false
+    }
+    sum := 0
+    for i := 0; i < 13; i++ {
+        if i % 2 == 0 {
+            sum += int(s[i] - '0')
+        } else {
+            sum += int(s[i] - '0') * 3
+        }
+    }
+    return sum % 10 == 0
+}
+
+// UUIDVersions the versions of UUID, such as UUID(4,7)
+type UUIDVersions []string
+
+// ParseUUIDVersions check the versions are 1-8, such as ParseUUIDVersions("4", "7")
+func ParseUUIDVersions(versions ...string) (UUIDVersions, error) {
+    var vs UUIDVersions
+    for _, version := range versions {
+        version = strings.TrimSpace(version)
+        if len(version) == 0 {
+            continue
+        }
+        if len(version) != 1 || version[0] < '1' || version[0] > '8' {
+            return nil, fmt.Errorf("doesn't exist %s UUID version", version)
+        }
+        vs = append(vs, version)
+    }
+    return vs, nil
+}
+
+// UUID check the string is a UUID, such as 123e4567-e89b-12d3-a456-426614174000
+// the version must be one of Versions if it is not empty, such as UUID(4)
+type UUID struct {
+    Versions UUIDVersions
+    Key      string
+}
+
+// IsSatisfied judge whether obj is valid
+func (u UUID) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    if !ok || !uuidPattern.MatchString(str) {
+        return false
+    }
+    if len(u.Versions) == 0 {
+        return true
+    }
+    // the variant of RFC 4122
+    if !strings.ContainsRune("89abAB", rune(str[19])) {
+        return false
+    }
+    for _, version := range u.Versions {
+        if version == str[14:15] {
+            return true
+        }
+    }
+    return false
+}
+
+// DefaultMessage return the default UUID error message
+func (u UUID) DefaultMessage() string {
+    if len(u.Versions) == 0 {
+        return fmt.Sprintf(MessageTmpls["UUID"], fetchFieldName(u.Key))
+    }
+    return fmt.Sprintf(MessageTmpls["UUIDVersion"], fetchFieldName(u.Key), strings.Join(u.Versions, ","))
+}
+
+// GetKey return the u.Key
+func (u UUID) GetKey() string {
+    return u.Key
+}
+
+// GetLimitValue return the allowed versions
+func (u UUID) GetLimitValue() interface{} {
+    return u.Versions
+}
+
+// ULID check the string is a ULID, such as 01ARZ3NDEKTSV4RRFFQ69G5FAV
+type ULID struct {
+    Match
+    Key string
+}
+
+// DefaultMessage return the default ULID error message
+func (u ULID) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["ULID"], fetchFieldName(u.Key))
+}
+
+// GetKey return the u.Key
+func (u ULID) GetKey() string {
+    return u.Key
+}
+
+// GetLimitValue return the limit value
+func (u ULID) GetLimitValue() interface{} {
+    return nil
+}
+
+// Semver check the string is a semantic version 2.0.0, such as 1.2.3-beta.1+build.5
+type Semver struct {
+    Match
+    Key string
+}
+
+// DefaultMessage return the default Semver error message
+func (s Semver) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["Semver"], fetchFieldName(s.Key))
+}
+
+// GetKey return the s.Key
+func (s Semver) GetKey() string {
+    return s.Key
+}
+
+// GetLimitValue return the limit value
+func (s Semver) GetLimitValue() interface{} {
+    return nil
+}
+
+// Hexadecimal check the string is a hexadecimal number, the 0x prefix is allowed
+type Hexadecimal struct {
+    Match
+    Key string
+}
+
+// DefaultMessage return the default Hexadecimal error message
+func (h Hexadecimal) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["Hexadecimal"], fetchFieldName(h.Key))
+}
+
+// GetKey return the h.Key
+func (h Hexadecimal) GetKey() string {
+    return h.Key
+}
+
+// GetLimitValue return the limit value
+func (h Hexadecimal) GetLimitValue() interface{} {
+    return nil
+}
+
+// HexColor check the string is a hex color, such as #fff, #ffff, #ffffff or #ffffffff
+type HexColor struct {
+    Match
+    Key string
+}
+
+// DefaultMessage return the default HexColor error message
+func (h HexColor) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["HexColor"], fetchFieldName(h.Key))
+}
+
+// GetKey return the h.Key
+func (h HexColor) GetKey() string {
+    return h.Key
+}
+
+// GetLimitValue return the limit value
+func (h HexColor) GetLimitValue() interface{} {
+    return nil
+}
+
+// RGB check the string is a css rgb color, such as rgb(255, 0, 0) or rgb(100%, 0%, 0%)
+type RGB struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (r RGB) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    return ok && parseColorArgs(str, "rgb", false)
+}
+
+// DefaultMessage return the default RGB error message
+func (r RGB) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["RGB"], fetchFieldName(r.Key))
+}
+
+// GetKey return the r.Key
+func (r RGB) GetKey() string {
+    return r.Key
+}
+
+// GetLimitValue return the limit value
+func (r RGB) GetLimitValue() interface{} {
+    return nil
+}
+
+// RGBA check the string is a css rgba color, such as rgba(255, 0, 0, 0.5)
+type RGBA struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (r RGBA) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    return ok && parseColorArgs(str, "rgba", true)
+}
+
+// DefaultMessage return the default RGBA error message
+func (r RGBA) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["RGBA"], fetchFieldName(r.Key))
+}
+
+// GetKey return the r.Key
+func (r RGBA) GetKey() string {
+    return r.Key
+}
+
+// GetLimitValue return the limit value
+func (r RGBA) GetLimitValue() interface{} {
+    return nil
+}
+
+// ISBN10 check the string is a 10 digits ISBN, the hyphens and spaces are allowed
+type ISBN10 struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (i ISBN10) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    return ok && isISBN10(str)
+}
+
+// DefaultMessage return the default ISBN10 error message
+func (i ISBN10) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["ISBN10"], fetchFieldName(i.Key))
+}
+
+// GetKey return the i.Key
+func (i ISBN10) GetKey() string {
+    return i.Key
+}
+
+// GetLimitValue return the limit value
+func (i ISBN10) GetLimitValue() interface{} {
+    return nil
+}
+
+// ISBN13 check the string is a 13 digits ISBN, the hyphens and spaces are allowed
+type ISBN13 struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (i ISBN13) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    return ok && isISBN13(str)
+}
+
+// DefaultMessage return the default ISBN13 error message
+func (i ISBN13) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["ISBN13"], fetchFieldName(i.Key))
+}
+
+// GetKey return the i.Key
+func (i ISBN13) GetKey() string {
+    return i.Key
+}
+
+// GetLimitValue return the limit value
+func (i ISBN13) GetLimitValue() interface{} {
+    return nil
+}
+
+// ObjectID check the string is a MongoDB ObjectID of 24 hex characters
+type ObjectID struct {
+    Match
+    Key string
+}
+
+// DefaultMessage return the default ObjectID error message
+func (o ObjectID) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["ObjectID"], fetchFieldName(o.Key))
+}
+
+// GetKey return the o.Key
+func (o ObjectID) GetKey() string {
+    return o.Key
+}
+
+// GetLimitValue return the limit value
+func (o ObjectID) GetLimitValue() interface{} {
+    return nil
+}
+
+// UUID Test that the obj is UUID if type is string, the version must be one of versions if it is not empty
+func (v *Validation) UUID(obj interface{}, versions UUIDVersions, key string, errDesc string) *Result {
+    return v.apply(UUID{versions, key}, obj, errDesc)
+}
+
+// ULID Test that the obj is ULID if type is string
+func (v *Validation) ULID(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(ULID{Match{Regexp: ulidPattern}, key}, obj, errDesc)
+}
+
+// Semver Test that the obj is semantic version if type is string
+func (v *Validation) Semver(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(Semver{Match{Regexp: semverPattern}, key}, obj, errDesc)
+}
+
+// Hexadecimal Test that the obj is hexadecimal number if type is string
+func (v *Validation) Hexadecimal(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(Hexadecimal{Match{Regexp: hexadecimalPattern}, key}, obj, errDesc)
+}
+
+// HexColor Test that the obj is hex color if type is string
+func (v *Validation) HexColor(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(HexColor{Match{Regexp: hexColorPattern}, key}, obj, errDesc)
+}
+
+// RGB Test that the obj is css rgb color if type is string
+func (v *Validation) RGB(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(RGB{key}, obj, errDesc)
+}
+
+// RGBA Test that the obj is css rgba color if type is string
+func (v *Validation) RGBA(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(RGBA{key}, obj, errDesc)
+}
+
+// ISBN10 Test that the obj is 10 digits ISBN if type is string
+func (v *Validation) ISBN10(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(ISBN10{key}, obj, errDesc)
+}
+
+// ISBN13 Test that the obj is 13 digits ISBN if type is string
+func (v *Validation) ISBN13(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(ISBN13{key}, obj, errDesc)
+}
+
+// ObjectID Test that the obj is MongoDB ObjectID if type is string
+func (v *Validation) ObjectID(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(ObjectID{Match{Regexp: objectIDPattern}, key}, obj, errDesc)
+}
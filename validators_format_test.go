@@ -0,0 +1,141 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "testing"
+)
+
+func TestParseUUIDVersions(t *testing.T) {
+    tests := []struct {
+        versions []string
+        ok       bool
+    }{
+        {nil, true},
+        {[]string{"4"}, true},
+        {[]string{"1", " 7 ", "8"}, true},
+        {[]string{"0"}, false},
+        {[]string{"9"}, false},
+        {[]string{"10"}, false},
+        {[]string{"v4"}, false},
+    }
+    for _, test := range tests {
+        if _, err := ParseUUIDVersions(test.versions...); (err == nil) != test.ok {
+            t.Errorf("ParseUUIDVersions(%v) error = %v, want ok %v", test.versions, err, test.ok)
+        }
+    }
+}
+
+func TestUUID(t *testing.T) {
+    tests := []struct {
+        obj      interface{}
+        versions []string
+        want     bool
+    }{
+        {"123e4567-e89b-12d3-a456-426614174000", nil, true},
+        {"123E4567-E89B-12D3-A456-426614174000", nil, true},
+        {"00000000-0000-0000-0000-000000000000", nil, true},
+        {"123e4567e89b12d3a456426614174000", nil, false},
+        {"123e4567-e89b-12d3-a456-42661417400", nil, false},
+        {"g23e4567-e89b-12d3-a456-426614174000", nil, false},
+        {"", nil, false},
+        {123, nil, false},
+        {"f47ac10b-58cc-4372-a567-0e02b2c3d479", []string{"4"}, true},
+        {"f47ac10b-58cc-4372-a567-0e02b2c3d479", []string{"1", "7"}, false},
+        {"01890a5d-ac96-774b-bcce-b302099a8057", []string{"4", "7"}, true},
+        // the variant must be RFC 4122
+        {"f47ac10b-58cc-4372-c567-0e02b2c3d479", []string{"4"}, false},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        versions, err := ParseUUIDVersions(test.versions...)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if valid.UUID(test.obj, versions, "id.UUID", "").Ok != test.want {
+            t.Errorf("UUID(%v, %v) should be %v", test.obj, test.versions, test.want)
+        }
+    }
+}
+
+func TestUUIDTag(t *testing.T) {
+    type invalid struct {
+        ID string `valid:"UUID(4,9)"`
+    }
+    if err := (&Validation{}).Valid(invalid{}); err == nil || err.Error() != "doesn't exist 9 UUID version" {
+        t.Errorf("the invalid version should be reported by Valid, error = %v", err)
+    }
+}
+
+func TestFormats(t *testing.T) {
+    tests := []struct {
+        name string
+        obj  interface{}
+        want bool
+    }{
+        {"ULID", "01ARZ3NDEKTSV4RRFFQ69G5FAV", true},
+        {"ULID", "01arz3ndektsv4rrffq69g5fav", true},
+        {"ULID", "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", true},
+        // the timestamp overflows
+        {"ULID", "8ZZZZZZZZZZZZZZZZZZZZZZZZZ", false},
+        {"ULID", "01ARZ3NDEKTSV4RRFFQ69G5FA", false},
+        {"ULID", "01ARZ3NDEKTSV4RRFFQ69G5FAI", false},
+        {"ULID", "01ARZ3NDEKTSV4RRFFQ69G5FAU", false},
+        {"Semver", "1.2.3", true},
+        {"Semver", "0.0.0", true},
+        {"Semver", "1.2.3-beta.1+build.5", true},
+        {"Semver", "1.0.0-alpha-1", true},
+        {"Semver", "1.2", false},
+        {"Semver", "v1.2.3", false},
+        {"Semver", "01.2.3", false},
+        {"Semver", "1.2.3-01", false},
+        {"Semver", "1.2.3+", false},
+        {"ISBN10", "0-306-40615-2", true},
+        {"ISBN10", "080442957X", true},
+        {"ISBN10", "0 306 40615 2", true},
+        {"ISBN10", "0306406153", false},
+        {"ISBN10", "030640615", false},
+        {"ISBN10", "X306406152", false},
+        {"ISBN13", "978-0-306-40615-7", true},
+        {"ISBN13", "9791234567896", true},
+        {"ISBN13", "9780306406158", false},
+        {"ISBN13", "9770306406155", false},
+        {"ISBN13", "978030640615", false},
+        {"ObjectID", "507f1f77bcf86cd799439011", true},
+        {"ObjectID", "507F1F77BCF86CD799439011", true},
+        {"ObjectID", "507f1f77bcf86cd79943901", false},
+        {"ObjectID", "507f1f77bcf86cd79943901g", false},
+        {"ObjectID", 507, false},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        var r *Result
+        switch test.name {
+        case "ULID":
+            r = valid.ULID(test.obj, "id.ULID", "")
+        case "Semver":
+            r = valid.Semver(test.obj, "version.Semver", "")
+        case "ISBN10":
+            r = valid.ISBN10(test.obj, "book.ISBN10", "")
+        case "ISBN13":
+            r = valid.ISBN13(test.obj, "book.ISBN13", "")
+        case "ObjectID":
+            r = valid.ObjectID(test.obj, "id.ObjectID", "")
+        }
+        if r.Ok != test.want {
+            t.Errorf("%s(%v) should be %v", test.name, test.obj, test.want)
+        }
+    }
+}
@@ -0,0 +1,361 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "fmt"
+    "math"
+    "reflect"
+    "strconv"
+    "strings"
+    "time"
+    // embed the IANA time zone database, so Timezone doesn't depend on the system
+    _ "time/tzdata"
+)
+
+// the ISO 3166-1 country codes
+// key: alpha-2 code
+// value: alpha-3 code
+var countryCodes = map[string]string{
+    "AD": "AND", "AE": "ARE", "AF": "AFG", "AG": "ATG", "AI": "AIA", "AL": "ALB", "AM": "ARM",
+    "AO": "AGO", "AQ": "ATA", "AR": "ARG", "AS": "ASM", "AT": "AUT", "AU": "AUS", "AW": "ABW",
+    "AX": "ALA", "AZ": "AZE", "BA": "BIH", "BB": "BRB", "BD": "BGD", "BE": "BEL", "BF": "BFA",
+    "BG": "BGR", "BH": "BHR", "BI": "BDI", "BJ": "BEN", "BL": "BLM", "BM": "BMU", "BN": "BRN",
+    "BO": "BOL", "BQ": "BES", "BR": "BRA", "BS": "BHS", "BT": "BTN", "BV": "BVT", "BW": "BWA",
+    "BY": "BLR", "BZ": "BLZ", "CA": "CAN", "CC": "CCK", "CD": "COD", "CF": "CAF", "CG": "COG",
+    "CH": "CHE", "CI": "CIV", "CK": "COK", "CL": "CHL", "CM": "CMR", "CN": "CHN", "CO": "COL",
+    "CR": "CRI", "CU": "CUB", "CV": "CPV", "CW": "CUW", "CX": "CXR", "CY": "CYP", "CZ": "CZE",
+    "DE": "DEU", "DJ": "DJI", "DK": "DNK", "DM": "DMA", "DO": "DOM", "DZ": "DZA", "EC": "ECU",
+    "EE": "EST", "EG": "EGY", "EH": "ESH", "ER": "ERI", "ES": "ESP", "ET": "ETH", "FI": "FIN",
+    "FJ": "FJI", "FK": "FLK", "FM": "FSM", "FO": "FRO", "FR": "FRA", "GA": "GAB", "GB": "GBR",
+    "GD": "GRD", "GE": "GEO", "GF": "GUF", "GG": "GGY", "GH": "GHA", "GI": "GIB", "GL": "GRL",
+    "GM": "GMB", "GN": "GIN", "GP": "GLP", "GQ": "GNQ", "GR": "GRC", "GS": "SGS", "GT": "GTM",
+    "GU": "GUM", "GW": "GNB", "GY": "GUY", "HK": "HKG", "HM": "HMD", "HN": "HND", "HR": "HRV",
+    "HT": "HTI", "HU": "HUN", "ID": "IDN", "IE": "IRL", "IL": "ISR", "IM": "IMN", "IN": "IND",
+    "IO": "IOT", "IQ": "IRQ", "IR": "IRN", "IS": "ISL", "IT": "ITA", "JE": "JEY", "JM": "JAM",
+    "JO": "JOR", "JP": "JPN", "KE": "KEN", "KG": "KGZ", "KH": "KHM", "KI": "KIR", "KM": "COM",
+    "KN": "KNA", "KP": "PRK", "KR": "KOR", "KW": "KWT", "KY": "CYM", "KZ": "KAZ", "LA": "LAO",
+    "LB": "LBN", "LC": "LCA", "LI": "LIE", "LK": "LKA", "LR": "LBR", "LS": "LSO", "LT": "LTU",
+    "LU": "LUX", "LV": "LVA", "LY": "LBY", "MA": "MAR", "MC": "MCO", "MD": "MDA", "ME": "MNE",
+    "MF": "MAF", "MG": "MDG", "MH": "MHL", "MK": "MKD", "ML": "MLI", "MM": "MMR", "MN": "MNG",
+    "MO": "MAC", "MP": "MNP", "MQ": "MTQ", "MR": "MRT", "MS": "MSR", "MT": "MLT", "MU": "MUS",
+    "MV": "MDV", "MW": "MWI", "MX": "MEX", "MY": "MYS", "MZ": "MOZ", "NA": "NAM", "NC": "NCL",
+    "NE": "NER", "NF": "NFK", "NG": "NGA", "NI": "NIC", "NL": "NLD", "NO": "NOR", "NP": "NPL",
+    "NR": "NRU", "NU": "NIU", "NZ": "NZL", "OM": "OMN", "PA": "PAN", "PE": "PER", "PF": "PYF",
+    "PG": "PNG", "PH": "PHL", "PK": "PAK", "PL": "POL", "PM": "SPM", "PN": "PCN", "PR": "PRI",
+    "PS": "PSE", "PT": "PRT", "PW": "PLW", "PY": "PRY", "QA": "QAT", "RE": "REU", "RO": "ROU",
+    "RS": "SRB", "RU": "RUS", "RW": "RWA", "SA": "SAU", "SB": "SLB", "SC": "SYC", "SD": "SDN",
+    "SE": "SWE", "SG": "SGP", "SH": "SHN", "SI": "SVN", "SJ": "SJM", "SK": "SVK", "SL": "SLE",
+    "SM": "SMR", "SN": "SEN", "SO": "SOM", "SR": "SUR", "SS": "SSD", "ST": "STP", "SV": "SLV",
+    "SX": "SXM", "SY": "SYR", "SZ": "SWZ", "TC": "TCA", "TD": "TCD", "TF": "ATF", "TG": "TGO",
+    "TH": "THA", "TJ": "TJK", "TK": "TKL", "TL": "TLS", "TM": "TKM", "TN": "TUN", "TO": "TON",
+    "TR": "TUR", "TT": "TTO", "TV": "TUV", "TW": "TWN", "TZ": "TZA", "UA": "UKR", "UG": "UGA",
+    "UM": "UMI", "US": "USA", "UY": "URY", "UZ": "UZB", "VA": "VAT", "VC": "VCT", "VE": "VEN",
+    "VG": "VGB", "VI": "VIR", "VN": "VNM", "VU": "VUT", "WF": "WLF", "WS": "WSM", "YE": "YEM",
+    "YT": "MYT", "ZA": "ZAF", "ZM": "ZMB", "ZW": "ZWE",
+}
+
+// the ISO 3166-1 alpha-3 country codes
+var countryAlpha3Codes = make(map[string]bool, len(countryCodes))
+
+func init() {
+    for _, alpha3 := range countryCodes {
+        countryAlpha3Codes[alpha3] = true
+    }
+}
+
+// the ISO 639-1 language codes
+var languageCodes = map[string]bool{
+    "aa": true, "ab": true, "ae": true, "af": true, "ak": true, "am": true, "an": true, "ar": true,
+    "as": true, "av": true, "ay": true, "az": true, "ba": true, "be": true, "bg": true, "bi": true,
+    "bm": true, "bn": true, "bo": true, "br": true, "bs": true, "ca": true, "ce": true, "ch": true,
+    "co": true, "cr": true, "cs": true, "cu": true, "cv": true, "cy": true, "da": true, "de": true,
+    "dv": true, "dz": true, "ee": true, "el": true, "en": true, "eo": true, "es": true, "et": true,
+    "eu": true, "fa": true, "ff": true, "fi": true, "fj": true, "fo": true, "fr": true, "fy": true,
+    "ga": true, "gd": true, "gl": true, "gn": true, "gu": true, "gv": true, "ha": true, "he": true,
+    "hi": true, "ho": true, "hr": true, "ht": true, "hu": true, "hy": true, "hz": true, "ia": true,
+    "id": true, "ie": true, "ig": true, "ii": true, "ik": true, "io": true, "is": true, "it": true,
+    "iu": true, "ja": true, "jv": true, "ka": true, "kg": true, "ki": true, "kj": true, "kk": true,
+    "kl": true, "km": true, "kn": true, "ko": true, "kr": true, "ks": true, "ku": true, "kv": true,
+    "kw": true, "ky": true, "la": true, "lb": true, "lg": true, "li": true, "ln": true, "lo": true,
+    "lt": true, "lu": true, "lv": true, "mg": true, "mh": true, "mi": true, "mk": true, "ml": true,
+    "mn": true, "mr": true, "ms": true, "mt": true, "my": true, "na": true, "nb": true, "nd": true,
+    "ne": true, "ng": true, "nl": true, "nn": true, "no": true, "nr": true, "nv": true, "ny": true,
+    "oc": true, "oj": true, "om": true, "or": true, "os": true, "pa": true, "pi": true, "pl": true,
+    "ps": true, "pt": true, "qu": true, "rm": true, "rn": true, "ro": true, "ru": true, "rw": true,
+    "sa": true, "sc": true, "sd": true, "se": true, "sg": true, "si": true, "sk": true, "sl": true,
+    "sm": true, "sn": true, "so": true, "sq": true, "sr": true, "ss": true, "st": true, "su": true,
+    "sv": true, "sw": true, "ta": true, "te": true, "tg": true, "th": true, "ti": true, "tk": true,
+    "tl": true, "tn": true, "to": true, "tr": true, "ts": true, "tt": true, "tw": true, "ty": true,
+    "ug": true, "uk": true, "ur": true, "uz": true, "ve": true, "vi": true, "vo": true, "wa": true,
+    "wo": true, "xh": true, "yi": true, "yo": true, "za": true, "zh": true, "zu": true,
+}
+
+// toFloat convert obj to float64, obj can be a number or a numeric string
+func toFloat(obj interface{}) (float64, bool) {
+    if str, ok := obj.(string); ok {
+        f, err := strconv.ParseFloat(strings.TrimSpace(str), 64)
+        return f, err == nil && !math.IsNaN(f) && !math.IsInf(f, 0)
+    }
+    v := reflect.ValueOf(obj)
+    switch v.Kind() {
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+        return float64(v.Int()), true
+    case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+        return float64(v.Uint()), true
+    case reflect.Float32, reflect.Float64:
+        f := v.Float()
+        return f, !math.IsNaN(f) && !math.IsInf(f, 0)
+    }
+    return 0, false
+}
+
+func isLatitude(obj interface{}) bool {
+    f, ok := toFloat(obj)
+    return ok && -90 <= f && f <= 90
+}
+
+func isLongitude(obj interface{}) bool {
+    f, ok := toFloat(obj)
+    return ok && -180 <= f && f <= 180
+}
+
+// Latitude check the number or numeric string is a latitude between -90 and 90
+type Latitude struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (l Latitude) IsSatisfied(obj interface{}) bool {
+    return isLatitude(obj)
+}
+
+// DefaultMessage return the default Latitude error message
+func (l Latitude) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["Latitude"], fetchFieldName(l.Key))
+}
+
+// GetKey return the l.Key
+func (l Latitude) GetKey() string {
+    return l.Key
+}
+
+// GetLimitValue return the limit value
+func (l Latitude) GetLimitValue() interface{} {
+    return nil
+}
+
+// Longitude check the number or numeric string is a longitude between -180 and 180
+type Longitude struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (l Longitude) IsSatisfied(obj interface{}) bool {
+    return isLongitude(obj)
+}
+
+// DefaultMessage return the default Longitude error message
+func (l Longitude) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["Longitude"], fetchFieldName(l.Key))
+}
+
+// GetKey return the l.Key
+func (l Longitude) GetKey() string {
+    return l.Key
+}
+
+// GetLimitValue return the limit value
+func (l Longitude) GetLimitValue() interface{} {
+    return nil
+}
+
+// LatLng check the string is a coordinate in the format of "latitude,longitude", such as 39.9042,116.4074
+type LatLng struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (l LatLng) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    if !ok {
+        return false
+    }
+    parts := strings.Split(str, ",")
+    return len(parts) == 2 && isLatitude(parts[0]) && isLongitude(parts[1])
+}
+
+// DefaultMessage return the default LatLng error message
+func (l LatLng) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["LatLng"], fetchFieldName(l.Key))
+}
+
+// GetKey return the l.Key
+func (l LatLng) GetKey() string {
+    return l.Key
+}
+
+// GetLimitValue return the limit value
+func (l LatLng) GetLimitValue() interface{} {
+    return nil
+}
+
+// Timezone check the string is an IANA time zone name, such as Asia/Shanghai or UTC
+type Timezone struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (t Timezone) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    // time.LoadLocation return UTC for the empty string and the local time zone for Local
+    if !ok || len(str) == 0 || str == "Local" {
+        return false
+    }
+    _, err := time.LoadLocation(str)
+    return err == nil
+}
+
+// DefaultMessage return the default Timezone error message
+func (t Timezone) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["Timezone"], fetchFieldName(t.Key))
+}
+
+// GetKey return the t.Key
+func (t Timezone) GetKey() string {
+    return t.Key
+}
+
+// GetLimitValue return the limit value
+func (t Timezone) GetLimitValue() interface{} {
+    return nil
+}
+
+// CountryAlpha2 check the string is an ISO 3166-1 alpha-2 country code in upper case, such as CN
+type CountryAlpha2 struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (c CountryAlpha2) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    if !ok {
+        return false
+    }
+    _, ok = countryCodes[str]
+    return ok
+}
+
+// DefaultMessage return the default CountryAlpha2 error message
+func (c CountryAlpha2) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["CountryAlpha2"], fetchFieldName(c.Key))
+}
+
+// GetKey return the c.Key
+func (c CountryAlpha2) GetKey() string {
+    return c.Key
+}
+
+// GetLimitValue return the limit value
+func (c CountryAlpha2) GetLimitValue() interface{} {
+    return nil
+}
+
+// CountryAlpha3 check the string is an ISO 3166-1 alpha-3 country code in upper case, such as CHN
+type CountryAlpha3 struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (c CountryAlpha3) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    return ok && countryAlpha3Codes[str]
+}
+
+// DefaultMessage return the default CountryAlpha3 error message
+func (c CountryAlpha3) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["CountryAlpha3"], fetchFieldName(c.Key))
+}
+
+// GetKey return the c.Key
+func (c CountryAlpha3) GetKey() string {
+    return c.Key
+}
+
+// GetLimitValue return the limit value
+func (c CountryAlpha3) GetLimitValue() interface{} {
+    return nil
+}
+
+// Language check the string is an ISO 639-1 language code in lower case, such as zh
+type Language struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (l Language) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    return ok && languageCodes[str]
+}
+
+// DefaultMessage return the default Language error message
+func (l Language) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["Language"], fetchFieldName(l.Key))
+}
+
+// GetKey return the l.Key
+func (l Language) GetKey() string {
+    return l.Key
+}
+
+// GetLimitValue return the limit value
+func (l Language) GetLimitValue() interface{} {
+    return nil
+}
+
+// Latitude Test that the obj is latitude if type is number or string
+func (v *Validation) Latitude(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(Latitude{key}, obj, errDesc)
+}
+
+// Longitude Test that the obj is longitude if type is number or string
+func (v *Validation) Longitude(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(Longitude{key}, obj, errDesc)
+}
+
+// LatLng Test that the obj is "latitude,longitude" if type is string
+func (v *Validation) LatLng(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(LatLng{key}, obj, errDesc)
+}
+
+// Timezone Test that the obj is IANA time zone name if type is string
+func (v *Validation) Timezone(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(Timezone{key}, obj, errDesc)
+}
+
+// CountryAlpha2 Test that the obj is ISO 3166-1 alpha-2 country code if type is string
+func (v *Validation) CountryAlpha2(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(CountryAlpha2{key}, obj, errDesc)
+}
+
+// CountryAlpha3 Test that the obj is ISO 3166-1 alpha-3 country code if type is string
+func (v *Validation) CountryAlpha3(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(CountryAlpha3{key}, obj, errDesc)
+}
+
+// Language Test that the obj is ISO 639-1 language code if type is string
+func (v *Validation) Language(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(Language{key}, obj, errDesc)
+}
@@ -0,0 +1,119 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "math"
+    "testing"
+)
+
+func TestCoordinates(t *testing.T) {
+    tests := []struct {
+        name string
+        obj  interface{}
+        want bool
+    }{
+        {"Latitude", 39.9042, true},
+        {"Latitude", -90, true},
+        {"Latitude", uint8(90), true},
+        {"Latitude", "39.9042", true},
+        {"Latitude", " -45.5 ", true},
+        {"Latitude", 90.0001, false},
+        {"Latitude", "-91", false},
+        {"Latitude", "NaN", false},
+        {"Latitude", math.NaN(), false},
+        {"Latitude", "north", false},
+        {"Latitude", true, false},
+        {"Longitude", 116.4074, true},
+        {"Longitude", -180, true},
+        {"Longitude", "180", true},
+        {"Longitude", 180.5, false},
+        {"Longitude", "Inf", false},
+        {"Longitude", math.Inf(-1), false},
+        {"LatLng", "39.9042,116.4074", true},
+        {"LatLng", "39.9042, 116.4074", true},
+        {"LatLng", "-90,-180", true},
+        {"LatLng", "116.4074,39.9042", false},
+        {"LatLng", "39.9042", false},
+        {"LatLng", "39.9042,116.4074,0", false},
+        {"LatLng", ",116.4074", false},
+        {"LatLng", 39.9042, false},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        var r *Result
+        switch test.name {
+        case "Latitude":
+            r = valid.Latitude(test.obj, "geo.Latitude", "")
+        case "Longitude":
+            r = valid.Longitude(test.obj, "geo.Longitude", "")
+        case "LatLng":
+            r = valid.LatLng(test.obj, "geo.LatLng", "")
+        }
+        if r.Ok != test.want {
+            t.Errorf("%s(%v) should be %v", test.name, test.obj, test.want)
+        }
+    }
+}
+
+func TestCodes(t *testing.T) {
+    tests := []struct {
+        name string
+        obj  interface{}
+        want bool
+    }{
+        {"Timezone", "Asia/Shanghai", true},
+        {"Timezone", "America/New_York", true},
+        {"Timezone", "UTC", true},
+        {"Timezone", "Local", false},
+        {"Timezone", "", false},
+        {"Timezone", "Asia/Beijing", false},
+        {"Timezone", "../etc/passwd", false},
+        {"Timezone", 8, false},
+        {"CountryAlpha2", "CN", true},
+        {"CountryAlpha2", "US", true},
+        {"CountryAlpha2", "cn", false},
+        {"CountryAlpha2", "XX", false},
+        {"CountryAlpha2", "CHN", false},
+        {"CountryAlpha3", "CHN", true},
+        {"CountryAlpha3", "USA", true},
+        {"CountryAlpha3", "chn", false},
+        {"CountryAlpha3", "CN", false},
+        {"CountryAlpha3", "XXX", false},
+        {"Language", "zh", true},
+        {"Language", "en", true},
+        {"Language", "ZH", false},
+        {"Language", "zho", false},
+        {"Language", "xx", false},
+        {"Language", "", false},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        var r *Result
+        switch test.name {
+        case "Timezone":
+            r = valid.Timezone(test.obj, "geo.Timezone", "")
+        case "CountryAlpha2":
+            r = valid.CountryAlpha2(test.obj, "geo.CountryAlpha2", "")
+        case "CountryAlpha3":
+            r = valid.CountryAlpha3(test.obj, "geo.CountryAlpha3", "")
+        case "Language":
+            r = valid.Language(test.obj, "geo.Language", "")
+        }
+        if r.Ok != test.want {
+            t.Errorf("%s(%v) should be %v", test.name, test.obj, test.want)
+        }
+    }
+}
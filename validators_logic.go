@@ -0,0 +1,247 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "fmt"
+    "reflect"
+    "regexp"
+    "strings"
+)
+
+// checkRules apply the rules to obj in a new validation context, all the rules must match, or at least one
+// of them if anyOf is true, it stops at the first failed rule, or the first matched rule if anyOf is true,
+// and return the errors of the failed rules, the field name is not in the messages, such as "无效的邮箱地址",
+// so they can be combined by Or and And
+func checkRules(current reflect.Value, rules Rules, obj interface{}, anyOf bool) (errs []*Error, ok bool) {
+    for _, rule := range rules {
+        v := &Validation{current: current}
+        err := v.applyRules(Rules{rule}, obj, "", "").Error
+        if err == nil {
+            if anyOf {
+                return nil, true
+            }
+            continue
+        }
+        err.Message = strings.TrimSpace(err.Message)
+        errs = append(errs, err)
+        if !anyOf {
+            return errs, false
+        }
+    }
+    return errs, !anyOf
+}
+
+// describeRule return the name and the parameters of the rule, such as In(admin,root)
+func describeRule(rule ValidFunc) string {
+    var params []string
+    // skip the key
+    for _, param := range rule.Params[:len(rule.Params) - 1] {
+        switch p := param.(type) {
+        case []string:
+            params = append(params, p...)
+        case Rules:
+            params = append(params, describeRules(p, ","))
+        case *regexp.Regexp:
+            params = append(params, "/" + p.String() + "/")
+        case fmt.Stringer:
+            params = append(params, p.String())
+        default:
+            // the parsed options, such as EmailOptions, are not described
+            if reflect.TypeOf(p).Kind() != reflect.Struct {
+                params = append(params, fmt.Sprint(p))
+            }
+        }
+    }
+    if len(params) == 0 {
+        return ruleName(rule.Name)
+    }
+    return ruleName(rule.Name) + "(" + strings.Join(params, ",") + ")"
+}
+
+func describeRules(rules Rules, sep string) string {
+    descs := make([]string, len(rules))
+    for i, rule := range rules {
+        descs[i] = describeRule(rule)
+    }
+    return strings.Join(descs, sep)
+}
+
+// logicResult the result of the rules of Or, And and Not for a value
+type logicResult struct {
+    errs []*Error
+    ok   bool
+}
+
+// Or check obj matches at least one of the rules, such as Or(Email,Mobile),
+// the rules can be grouped by And, such as Or(Email,And(Numeric,Length(11)))
+type Or struct {
+    Rules Rules
+    Key   string
+
+    // the struct being validated, for the cross field references of the rules
+    current reflect.Value
+    // the result computed once by Validation.Or
+    result *logicResult
+}
+
+func (o Or) check(obj interface{}) logicResult {
+    if o.result != nil {
+        return *o.result
+    }
+    errs, ok := checkRules(o.current, o.Rules, obj, true)
+    return logicResult{errs, ok}
+}
+
+// Reason return the messages of all the rules if none of them matches
+func (o Or) Reason(obj interface{}) (string, string) {
+    r := o.check(obj)
+    if r.ok {
+        return "", ""
+    }
+    messages := make([]string, len(r.errs))
+    for i, err := range r.errs {
+        messages[i] = err.Message
+    }
+    return "", fmt.Sprintf(MessageTmpls["Or"], fetchFieldName(o.Key), strings.Join(messages, OrSeparator))
+}
+
+// IsSatisfied judge whether obj is valid
+func (o Or) IsSatisfied(obj interface{}) bool {
+    return o.check(obj).ok
+}
+
+// DefaultMessage return the default Or error message
+func (o Or) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["Or"], fetchFieldName(o.Key), describeRules(o.Rules, OrSeparator))
+}
+
+// GetKey return the o.Key
+func (o Or) GetKey() string {
+    return o.Key
+}
+
+// GetLimitValue return the limit value
+func (o Or) GetLimitValue() interface{} {
+    return nil
+}
+
+// And check obj matches all the rules, it is used to group the rules in Or and Not,
+// such as Not(And(Numeric,Length(6)))
+type And struct {
+    Rules Rules
+    Key   string
+
+    // the struct being validated, for the cross field references of the rules
+    current reflect.Value
+    // the result computed once by Validation.And
+    result *logicResult
+}
+
+func (a And) check(obj interface{}) logicResult {
+    if a.result != nil {
+        return *a.result
+    }
+    errs, ok := checkRules(a.current, a.Rules, obj, false)
+    return logicResult{errs, ok}
+}
+
+// Reason return the message of the first failed rule
+func (a And) Reason(obj interface{}) (string, string) {
+    r := a.check(obj)
+    if r.ok {
+        return "", ""
+    }
+    return "", fmt.Sprintf(MessageTmpls["And"], fetchFieldName(a.Key), r.errs[0].Message)
+}
+
+// IsSatisfied judge whether obj is valid
+func (a And) IsSatisfied(obj interface{}) bool {
+    return a.check(obj).ok
+}
+
+// DefaultMessage return the default And error message
+func (a And) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["And"], fetchFieldName(a.Key), describeRules(a.Rules, AndSeparator))
+}
+
+// GetKey return the a.Key
+func (a And) GetKey() string {
+    return a.Key
+}
+
+// GetLimitValue return the limit value
+func (a And) GetLimitValue() interface{} {
+    return nil
+}
+
+// Not check obj doesn't match the rules, such as Not(In(admin,root)),
+// the rules are combined by And if there are more than one
+type Not struct {
+    Rules Rules
+    Key   string
+
+    // the struct being validated, for the cross field references of the rules
+    current reflect.Value
+    // the result computed once by Validation.Not
+    result *logicResult
+}
+
+func (n Not) check(obj interface{}) logicResult {
+    if n.result != nil {
+        return *n.result
+    }
+    errs, ok := checkRules(n.current, n.Rules, obj, false)
+    return logicResult{errs, ok}
+}
+
+// IsSatisfied judge whether obj is valid
+func (n Not) IsSatisfied(obj interface{}) bool {
+    return !n.check(obj).ok
+}
+
+// DefaultMessage return the default Not error message, the matched rules are described by
+// their names and parameters, such as In(admin,root)
+func (n Not) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["Not"], fetchFieldName(n.Key), describeRules(n.Rules, AndSeparator))
+}
+
+// GetKey return the n.Key
+func (n Not) GetKey() string {
+    return n.Key
+}
+
+// GetLimitValue return the limit value
+func (n Not) GetLimitValue() interface{} {
+    return nil
+}
+
+// Or Test that the obj matches at least one of the rules, such as Or(Email,Mobile)
+func (v *Validation) Or(obj interface{}, rules Rules, key string, errDesc string) *Result {
+    errs, ok := checkRules(v.current, rules, obj, true)
+    return v.apply(Or{rules, key, v.current, &logicResult{errs, ok}}, obj, errDesc)
+}
+
+// And Test that the obj matches all the rules, it is used to group the rules in Or and Not
+func (v *Validation) And(obj interface{}, rules Rules, key string, errDesc string) *Result {
+    errs, ok := checkRules(v.current, rules, obj, false)
+    return v.apply(And{rules, key, v.current, &logicResult{errs, ok}}, obj, errDesc)
+}
+
+// Not Test that the obj doesn't match the rules, such as Not(In(admin,root))
+func (v *Validation) Not(obj interface{}, rules Rules, key string, errDesc string) *Result {
+    errs, ok := checkRules(v.current, rules, obj, false)
+    return v.apply(Not{rules, key, v.current, &logicResult{errs, ok}}, obj, errDesc)
+}
@@ -0,0 +1,91 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "testing"
+)
+
+type logicForm struct {
+    Contact string `valid:"Or(Email,Mobile)"`
+    Code    string `valid:"Or(Length(4),And(Numeric,Length(6)))"`
+    Role    string `valid:"Not(In(admin,root))"`
+    Pin     string `valid:"Not(Numeric,Length(4))"`
+    Name    string `valid:"And(Required,MaxSize(3))"`
+}
+
+func TestLogicRules(t *testing.T) {
+    valid := logicForm{Contact: "a@b.com", Code: "1234", Role: "user", Pin: "12345", Name: "abc"}
+    tests := []struct {
+        f       logicForm
+        key     string
+        message string
+        en      string
+    }{
+        {valid, "", "", ""},
+        {logicForm{Contact: "13800138000", Code: "123456", Role: "user", Pin: "abcd", Name: "abc"}, "", "", ""},
+        {logicForm{Contact: "x"}, "Contact.Or",
+            "Contact 不符合以下任一条件：无效的email；无效的手机号",
+            "Contact must satisfy one of: must be a valid email address; must be valid mobile number"},
+        {logicForm{Contact: "a@b.com", Code: "12345a"}, "Code.Or",
+            "Code 不符合以下任一条件：必须为 4 个字符；不符合全部条件：无效的数字",
+            "Code must satisfy one of: required length is 4; must satisfy all of: must be valid numeric characters"},
+        {logicForm{Contact: "a@b.com", Code: "1234", Role: "root"}, "Role.Not",
+            "Role 不能符合：In(admin,root)",
+            "Role must not satisfy: In(admin,root)"},
+        {logicForm{Contact: "a@b.com", Code: "1234", Pin: "1234"}, "Pin.Not",
+            "Pin 不能符合：Numeric，且Length(4)",
+            "Pin must not satisfy: Numeric, and Length(4)"},
+        {logicForm{Contact: "a@b.com", Code: "1234", Pin: "12345", Name: "abcd"}, "Name.And",
+            "Name 不符合全部条件：不能长于 3 个字符",
+            "Name must satisfy all of: maximum size is 3"},
+    }
+    t.Cleanup(func() {
+        SetLocale("zh")
+    })
+    for _, locale := range []string{"zh", "en"} {
+        if err := SetLocale(locale); err != nil {
+            t.Fatal(err)
+        }
+        for _, test := range tests {
+            v := Validation{}
+            if err := v.Valid(test.f); err != nil && !v.HasErrors() {
+                t.Fatal(err)
+            }
+            key, message := "", ""
+            if v.HasErrors() {
+                key, message = v.Errors[0].Key, v.Errors[0].Message
+            }
+            want := test.message
+            if locale == "en" {
+                want = test.en
+            }
+            if key != test.key || message != want {
+                t.Errorf("Valid(%+v) in %s = %s %q, want %s %q", test.f, locale, key, message, test.key, want)
+            }
+        }
+    }
+}
+
+func TestLogicMessage(t *testing.T) {
+    type form struct {
+        Contact string `valid:"Or(Email,Mobile)" vdesc:"请填写邮箱或手机号"`
+    }
+    v := Validation{}
+    v.Valid(form{"x"})
+    if !v.HasErrors() || v.Errors[0].Message != "请填写邮箱或手机号" {
+        t.Errorf("the vdesc should replace the combined message, errors = %v", v.Errors)
+    }
+}
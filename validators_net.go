@@ -0,0 +1,500 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "fmt"
+    "net"
+    "net/netip"
+    "reflect"
+    "strconv"
+    "strings"
+)
+
+// parseAddr parse the IP address, the IPv6 address with zone is invalid, such as fe80::1%eth0
+func parseAddr(obj interface{}) (netip.Addr, bool) {
+    str, ok := obj.(string)
+    if !ok {
+        return netip.Addr{}, false
+    }
+    addr, err := netip.ParseAddr(str)
+    return addr, err == nil && addr.Zone() == ""
+}
+
+// parsePrefix parse the CIDR notation, the host bits must be zero, such as 10.0.0.1/8 is invalid
+func parsePrefix(obj interface{}) (netip.Prefix, bool) {
+    str, ok := obj.(string)
+    if !ok {
+        return netip.Prefix{}, false
+    }
+    prefix, err := netip.ParsePrefix(str)
+    return prefix, err == nil && prefix == prefix.Masked()
+}
+
+// isHostname judge whether s is a valid host name by RFC 1123
+func isHostname(s string) bool {
+    s = strings.TrimSuffix(s, ".")
+    if len(s) == 0 || len(s) > 253 {
+        return false
+    }
+    for _, label := range strings.Split(s, ".") {
+        if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label) - 1] == '-' {
+            return false
+        }
+        for i := 0; i < len(label); i++ {
+            c := label[i]
+            if ('Z' < c || c < 'A') && ('z' < c || c < 'a') && ('9' < c || c < '0') && c != '-' {
+                return false
+            }
+        }
+    }
+    return true
+}
+
+// isPort judge whether obj is a port number between 1 and 65535, obj can be a string or an integer
+func isPort(obj interface{}) bool {
+    var port uint64
+    if str, ok := obj.(string); ok {
+        var err error
+        if port, err = strconv.ParseUint(str, 10, 16); err != nil {
+            return false
+        }
+        return port > 0
+    }
+    v := reflect.ValueOf(obj)
+    switch v.Kind() {
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+        return v.Int() > 0 && v.Int() <= 65535
+    case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+        return v.Uint() > 0 && v.Uint() <= 65535
+    }
+    return false
+}
+
+// IP check the string is an IPv4 or IPv6 address without zone
+// the embedded Match is kept for compatibility, its Regexp is used instead if it is set
+type IP struct {
+    Match
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (i IP) IsSatisfied(obj interface{}) bool {
+    if i.Regexp != nil {
+        return i.Match.IsSatisfied(obj)
+    }
+    _, ok := parseAddr(obj)
+    return ok
+}
+
+// DefaultMessage return the default IP error message
+func (i IP) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["IP"], fetchFieldName(i.Key))
+}
+
+// GetKey return the i.Key
+func (i IP) GetKey() string {
+    return i.Key
+}
+
+// GetLimitValue return the limit value
+func (i IP) GetLimitValue() interface{} {
+    return nil
+}
+
+// IPv4 check the string is an IPv4 address
+type IPv4 struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (i IPv4) IsSatisfied(obj interface{}) bool {
+    addr, ok := parseAddr(obj)
+    return ok && addr.Is4()
+}
+
+// DefaultMessage return the default IPv4 error message
+func (i IPv4) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["IPv4"], fetchFieldName(i.Key))
+}
+
+// GetKey return the i.Key
+func (i IPv4) GetKey() string {
+    return i.Key
+}
+
+// GetLimitValue return the limit value
+func (i IPv4) GetLimitValue() interface{} {
+    return nil
+}
+
+// IPv6 check the string is an IPv6 address without zone
+type IPv6 struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (i IPv6) IsSatisfied(obj interface{}) bool {
+    addr, ok := parseAddr(obj)
+    return ok && addr.Is6()
+}
+
+// DefaultMessage return the default IPv6 error message
+func (i IPv6) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["IPv6"], fetchFieldName(i.Key))
+}
+
+// GetKey return the i.Key
+func (i IPv6) GetKey() string {
+    return i.Key
+}
+
+// GetLimitValue return the limit value
+func (i IPv6) GetLimitValue() interface{} {
+    return nil
+}
+
+// CIDR check the string is an IPv4 or IPv6 CIDR notation, such as 10.0.0.0/8, the host bits must be zero
+type CIDR struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (c CIDR) IsSatisfied(obj interface{}) bool {
+    _, ok := parsePrefix(obj)
+    return ok
+}
+
+// DefaultMessage return the default CIDR error message
+func (c CIDR) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["CIDR"], fetchFieldName(c.Key))
+}
+
+// GetKey return the c.Key
+func (c CIDR) GetKey() string {
+    return c.Key
+}
+
+// GetLimitValue return the limit value
+func (c CIDR) GetLimitValue() interface{} {
+    return nil
+}
+
+// CIDRv4 check the string is an IPv4 CIDR notation
+type CIDRv4 struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (c CIDRv4) IsSatisfied(obj interface{}) bool {
+    prefix, ok := parsePrefix(obj)
+    return ok && prefix.Addr().Is4()
+}
+
+// DefaultMessage return the default CIDRv4 error message
+func (c CIDRv4) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["CIDRv4"], fetchFieldName(c.Key))
+}
+
+// GetKey return the c.Key
+func (c CIDRv4) GetKey() string {
+    return c.Key
+}
+
+// GetLimitValue return the limit value
+func (c CIDRv4) GetLimitValue() interface{} {
+    return nil
+}
+
+// CIDRv6 check the string is an IPv6 CIDR notation
+type CIDRv6 struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (c CIDRv6) IsSatisfied(obj interface{}) bool {
+    prefix, ok := parsePrefix(obj)
+    return ok && prefix.Addr().Is6()
+}
+
+// DefaultMessage return the default CIDRv6 error message
+func (c CIDRv6) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["CIDRv6"], fetchFieldName(c.Key))
+}
+
+// GetKey return the c.Key
+func (c CIDRv6) GetKey() string {
+    return c.Key
+}
+
+// GetLimitValue return the limit value
+func (c CIDRv6) GetLimitValue() interface{} {
+    return nil
+}
+
+// IPInSubnet check the string is an IP address in the subnet
+type IPInSubnet struct {
+    Subnet netip.Prefix
+    Key    string
+}
+
+// IsSatisfied judge whether obj is valid
+func (i IPInSubnet) IsSatisfied(obj interface{}) bool {
+    addr, ok := parseAddr(obj)
+    return ok && i.Subnet.Contains(addr)
+}
+
+// DefaultMessage return the default IPInSubnet error message
+func (i IPInSubnet) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["IPInSubnet"], fetchFieldName(i.Key), i.Subnet.String())
+}
+
+// GetKey return the i.Key
+func (i IPInSubnet) GetKey() string {
+    return i.Key
+}
+
+// GetLimitValue return the limit value
+func (i IPInSubnet) GetLimitValue() interface{} {
+    return i.Subnet.String()
+}
+
+// PrivateIP check the string is a private IP address (RFC 1918 and RFC 4193)
+type PrivateIP struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (p PrivateIP) IsSatisfied(obj interface{}) bool {
+    addr, ok := parseAddr(obj)
+    return ok && addr.IsPrivate()
+}
+
+// DefaultMessage return the default PrivateIP error message
+func (p PrivateIP) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["PrivateIP"], fetchFieldName(p.Key))
+}
+
+// GetKey return the p.Key
+func (p PrivateIP) GetKey() string {
+    return p.Key
+}
+
+// GetLimitValue return the limit value
+func (p PrivateIP) GetLimitValue() interface{} {
+    return nil
+}
+
+// the global unicast ranges which are not public
+var nonPublicPrefixes = []netip.Prefix{
+    // shared address space for carrier-grade NAT, RFC 6598
+    netip.MustParsePrefix("100.64.0.0/10"),
+    // documentation, RFC 5737 and RFC 3849
+    netip.MustParsePrefix("192.0.2.0/24"),
+    netip.MustParsePrefix("198.51.100.0/24"),
+    netip.MustParsePrefix("203.0.113.0/24"),
+    netip.MustParsePrefix("2001:db8::/32"),
+}
+
+// PublicIP check the string is a global unicast IP address, which is not private,
+// carrier-grade NAT (100.64.0.0/10) or documentation address (192.0.2.0/24, 198.51.100.0/24,
+// 203.0.113.0/24, 2001:db8::/32), the IPv4-mapped IPv6 address is checked as IPv4
+type PublicIP struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (p PublicIP) IsSatisfied(obj interface{}) bool {
+    addr, ok := parseAddr(obj)
+    if !ok {
+        return false
+    }
+    addr = addr.Unmap()
+    if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+        return false
+    }
+    for _, prefix := range nonPublicPrefixes {
+        if prefix.Contains(addr) {
+            return false
+        }
+    }
+    return true
+}
+
+// DefaultMessage return the default PublicIP error message
+func (p PublicIP) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["PublicIP"], fetchFieldName(p.Key))
+}
+
+// GetKey return the p.Key
+func (p PublicIP) GetKey() string {
+    return p.Key
+}
+
+// GetLimitValue return the limit value
+func (p PublicIP) GetLimitValue() interface{} {
+    return nil
+}
+
+// TCPAddr check the string is host:port, the host can be an IP address or a host name
+type TCPAddr struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (t TCPAddr) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    if !ok {
+        return false
+    }
+    host, port, err := net.SplitHostPort(str)
+    if err != nil || !isPort(port) {
+        return false
+    }
+    if _, err = netip.ParseAddr(host); err == nil {
+        return true
+    }
+    return isHostname(host)
+}
+
+// DefaultMessage return the default TCPAddr error message
+func (t TCPAddr) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["TCPAddr"], fetchFieldName(t.Key))
+}
+
+// GetKey return the t.Key
+func (t TCPAddr) GetKey() string {
+    return t.Key
+}
+
+// GetLimitValue return the limit value
+func (t TCPAddr) GetLimitValue() interface{} {
+    return nil
+}
+
+// Port check the string or integer is a port number between 1 and 65535
+type Port struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (p Port) IsSatisfied(obj interface{}) bool {
+    return isPort(obj)
+}
+
+// DefaultMessage return the default Port error message
+func (p Port) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["Port"], fetchFieldName(p.Key))
+}
+
+// GetKey return the p.Key
+func (p Port) GetKey() string {
+    return p.Key
+}
+
+// GetLimitValue return the limit value
+func (p Port) GetLimitValue() interface{} {
+    return nil
+}
+
+// MAC check the string is a MAC address, such as 00:00:5e:00:53:01
+type MAC struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (m MAC) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    if !ok {
+        return false
+    }
+    _, err := net.ParseMAC(str)
+    return err == nil
+}
+
+// DefaultMessage return the default MAC error message
+func (m MAC) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["MAC"], fetchFieldName(m.Key))
+}
+
+// GetKey return the m.Key
+func (m MAC) GetKey() string {
+    return m.Key
+}
+
+// GetLimitValue return the limit value
+func (m MAC) GetLimitValue() interface{} {
+    return nil
+}
+
+// IP Test that the obj is IPv4 or IPv6 address if type is string
+func (v *Validation) IP(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(IP{Key: key}, obj, errDesc)
+}
+
+// IPv4 Test that the obj is IPv4 address if type is string
+func (v *Validation) IPv4(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(IPv4{key}, obj, errDesc)
+}
+
+// IPv6 Test that the obj is IPv6 address if type is string
+func (v *Validation) IPv6(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(IPv6{key}, obj, errDesc)
+}
+
+// CIDR Test that the obj is IPv4 or IPv6 CIDR notation if type is string
+func (v *Validation) CIDR(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(CIDR{key}, obj, errDesc)
+}
+
+// CIDRv4 Test that the obj is IPv4 CIDR notation if type is string
+func (v *Validation) CIDRv4(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(CIDRv4{key}, obj, errDesc)
+}
+
+// CIDRv6 Test that the obj is IPv6 CIDR notation if type is string
+func (v *Validation) CIDRv6(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(CIDRv6{key}, obj, errDesc)
+}
+
+// IPInSubnet Test that the obj is IP address in the subnet if type is string
+func (v *Validation) IPInSubnet(obj interface{}, subnet netip.Prefix, key string, errDesc string) *Result {
+    return v.apply(IPInSubnet{subnet, key}, obj, errDesc)
+}
+
+// PrivateIP Test that the obj is private IP address if type is string
+func (v *Validation) PrivateIP(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(PrivateIP{key}, obj, errDesc)
+}
+
+// PublicIP Test that the obj is public IP address if type is string
+func (v *Validation) PublicIP(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(PublicIP{key}, obj, errDesc)
+}
+
+// TCPAddr Test that the obj is host:port if type is string
+func (v *Validation) TCPAddr(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(TCPAddr{key}, obj, errDesc)
+}
+
+// Port Test that the obj is port number if type is string or integer
+func (v *Validation) Port(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(Port{key}, obj, errDesc)
+}
+
+// MAC Test that the obj is MAC address if type is string
+func (v *Validation) MAC(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(MAC{key}, obj, errDesc)
+}
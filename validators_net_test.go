@@ -0,0 +1,165 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "net/netip"
+    "testing"
+)
+
+func TestNetAddrs(t *testing.T) {
+    tests := []struct {
+        name string
+        obj  interface{}
+        want bool
+    }{
+        {"IP", "192.168.1.1", true},
+        {"IP", "2001:db8::1", true},
+        {"IP", "fe80::1%eth0", false},
+        {"IP", "256.1.1.1", false},
+        {"IP", "", false},
+        {"IPv4", "10.0.0.1", true},
+        {"IPv4", "::1", false},
+        {"IPv6", "::1", true},
+        {"IPv6", "::ffff:10.0.0.1", true},
+        {"IPv6", "fe80::1%eth0", false},
+        {"IPv6", "10.0.0.1", false},
+        {"CIDR", "10.0.0.0/8", true},
+        {"CIDR", "2001:db8::/32", true},
+        {"CIDR", "10.0.0.1/32", true},
+        // the host bits must be zero
+        {"CIDR", "10.0.0.1/8", false},
+        {"CIDR", "2001:db8::1/32", false},
+        {"CIDR", "fe80::%eth0/64", false},
+        {"CIDR", "10.0.0.0", false},
+        {"CIDRv4", "192.168.0.0/16", true},
+        {"CIDRv4", "2001:db8::/32", false},
+        {"CIDRv6", "2001:db8::/32", true},
+        {"CIDRv6", "192.168.0.0/16", false},
+        {"PrivateIP", "10.1.2.3", true},
+        {"PrivateIP", "172.16.0.1", true},
+        {"PrivateIP", "fd00::1", true},
+        {"PrivateIP", "8.8.8.8", false},
+        {"PublicIP", "8.8.8.8", true},
+        {"PublicIP", "2606:4700::1111", true},
+        {"PublicIP", "::ffff:8.8.8.8", true},
+        {"PublicIP", "10.0.0.1", false},
+        {"PublicIP", "::ffff:10.0.0.1", false},
+        {"PublicIP", "127.0.0.1", false},
+        {"PublicIP", "169.254.0.1", false},
+        {"PublicIP", "100.64.0.1", false},
+        {"PublicIP", "100.128.0.1", true},
+        {"PublicIP", "192.0.2.1", false},
+        {"PublicIP", "198.51.100.1", false},
+        {"PublicIP", "203.0.113.1", false},
+        {"PublicIP", "2001:db8::1", false},
+        {"PublicIP", "224.0.0.1", false},
+        {"PublicIP", "0.0.0.0", false},
+        {"PublicIP", "fe80::1", false},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        var r *Result
+        switch test.name {
+        case "IP":
+            r = valid.IP(test.obj, "net.IP", "")
+        case "IPv4":
+            r = valid.IPv4(test.obj, "net.IPv4", "")
+        case "IPv6":
+            r = valid.IPv6(test.obj, "net.IPv6", "")
+        case "CIDR":
+            r = valid.CIDR(test.obj, "net.CIDR", "")
+        case "CIDRv4":
+            r = valid.CIDRv4(test.obj, "net.CIDRv4", "")
+        case "CIDRv6":
+            r = valid.CIDRv6(test.obj, "net.CIDRv6", "")
+        case "PrivateIP":
+            r = valid.PrivateIP(test.obj, "net.PrivateIP", "")
+        case "PublicIP":
+            r = valid.PublicIP(test.obj, "net.PublicIP", "")
+        }
+        if r.Ok != test.want {
+            t.Errorf("%s(%v) should be %v", test.name, test.obj, test.want)
+        }
+    }
+}
+
+func TestNetPorts(t *testing.T) {
+    tests := []struct {
+        name string
+        obj  interface{}
+        want bool
+    }{
+        {"Port", "1", true},
+        {"Port", "65535", true},
+        {"Port", 443, true},
+        {"Port", uint16(8080), true},
+        {"Port", "0", false},
+        {"Port", 0, false},
+        {"Port", "65536", false},
+        {"Port", 65536, false},
+        {"Port", -1, false},
+        {"Port", "+80", false},
+        {"Port", "80 ", false},
+        {"Port", 80.0, false},
+        {"TCPAddr", "example.com:443", true},
+        {"TCPAddr", "127.0.0.1:80", true},
+        {"TCPAddr", "[::1]:80", true},
+        {"TCPAddr", "::1:80", false},
+        {"TCPAddr", "example.com:0", false},
+        {"TCPAddr", "exa_mple.com:80", false},
+        {"TCPAddr", "example.com", false},
+        {"MAC", "00:00:5e:00:53:01", true},
+        {"MAC", "00-00-5E-00-53-01", true},
+        {"MAC", "0000.5e00.5301", true},
+        {"MAC", "00:00:5e:00:53", false},
+        {"MAC", "00:00:5e:00:53:0g", false},
+        {"MAC", 1, false},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        var r *Result
+        switch test.name {
+        case "Port":
+            r = valid.Port(test.obj, "net.Port", "")
+        case "TCPAddr":
+            r = valid.TCPAddr(test.obj, "net.TCPAddr", "")
+        case "MAC":
+            r = valid.MAC(test.obj, "net.MAC", "")
+        }
+        if r.Ok != test.want {
+            t.Errorf("%s(%v) should be %v", test.name, test.obj, test.want)
+        }
+    }
+}
+
+func TestIPInSubnet(t *testing.T) {
+    subnet := netip.MustParsePrefix("10.0.0.0/8")
+    tests := []struct {
+        obj  interface{}
+        want bool
+    }{
+        {"10.1.2.3", true},
+        {"11.0.0.1", false},
+        {"::ffff:10.0.0.1", false},
+        {"not an ip", false},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        if valid.IPInSubnet(test.obj, subnet, "net.IPInSubnet", "").Ok != test.want {
+            t.Errorf("IPInSubnet(%v, %s) should be %v", test.obj, subnet, test.want)
+        }
+    }
+}
@@ -0,0 +1,262 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "fmt"
+    "reflect"
+    "strconv"
+    "strings"
+    "unicode"
+)
+
+// the reasons of PasswordPolicy, see Error.Reason
+const (
+    PasswordTooShort    = "minLength"
+    PasswordNoLower     = "lower"
+    PasswordNoUpper     = "upper"
+    PasswordNoDigit     = "digit"
+    PasswordNoSymbol    = "symbol"
+    PasswordFewClasses  = "classes"
+    PasswordRepeated    = "repeat"
+    PasswordSequence    = "sequence"
+    PasswordHasUsername = "username"
+)
+
+// key: the character class
+// value: the message template name
+var passwordClassTmpls = map[string]string{
+    PasswordNoLower:  "PasswordLower",
+    PasswordNoUpper:  "PasswordUpper",
+    PasswordNoDigit:  "PasswordDigit",
+    PasswordNoSymbol: "PasswordSymbol",
+}
+
+// the default min length of the password
+const passwordMinLength = 8
+
+// PasswordPolicy check the password strength
+type PasswordPolicy struct {
+    Options  PasswordOptions
+    Username string
+    Key      string
+}
+
+// PasswordOptions the options of PasswordPolicy, the zero value requires the min length 8 only
+// Options in the tag, such as PasswordPolicy(min=10,classes=3,user=Username):
+//   min=N                        the min length of the password, 8 by default
+//   lower, upper, digit, symbol  the password must contain the character class
+//   classes=N                    the password must contain at least N of the 4 character classes
+//   repeat=N                     the password must not contain more than N same characters in a row, N >= 1
+//   sequence=N                   the password must not contain N or more sequential characters, such as 1234 or dcba, N >= 2
+//   user=Field                   the password must not contain the value of the exported field, case insensitive,
+//                                it can only be used by Valid
+type PasswordOptions struct {
+    MinLength int
+    Require   []string
+    Classes   int
+    Repeat    int
+    Sequence  int
+    UserField string
+}
+
+// ParsePasswordOptions parse the options of PasswordPolicy in the tag
+func ParsePasswordOptions(options ...string) (opts PasswordOptions, err error) {
+    for _, option := range options {
+        option = strings.TrimSpace(option)
+        name, value := option, ""
+        if index := strings.Index(option, "="); index >= 0 {
+            name, value = strings.TrimSpace(option[:index]), strings.TrimSpace(option[index + 1:])
+        }
+        switch name {
+        case "":
+        case PasswordNoLower, PasswordNoUpper, PasswordNoDigit, PasswordNoSymbol:
+            opts.Require = append(opts.Require, name)
+        case "user":
+            if len(value) == 0 {
+                return opts, fmt.Errorf("invalid PasswordPolicy option: %s", option)
+            }
+            opts.UserField = value
+        case "min", "classes", "repeat", "sequence":
+            n, err := strconv.Atoi(value)
+            // 1 character is a sequence of itself, so sequence must be at least 2
+            if err != nil || n < 0 || (name == "min" && n == 0) || (name == "classes" && n > 4) ||
+                (name == "repeat" && n < 1) || (name == "sequence" && n < 2) {
+                return opts, fmt.Errorf("invalid PasswordPolicy option: %s", option)
+            }
+            switch name {
+            case "min":
+                opts.MinLength = n
+            case "classes":
+                opts.Classes = n
+            case "repeat":
+                opts.Repeat = n
+            case "sequence":
+                opts.Sequence = n
+            }
+        default:
+            return opts, fmt.Errorf("invalid PasswordPolicy option: %s", option)
+        }
+    }
+    return
+}
+
+// checkPasswordUserField check the field of user= exists in the struct type st and can be read by Valid
+func checkPasswordUserField(st reflect.Type, name string) error {
+    if len(name) == 0 {
+        return nil
+    }
+    if st == nil {
+        return fmt.Errorf("PasswordPolicy(user=%s) can only be used by Valid", name)
+    }
+    if f, ok := st.FieldByName(name); !ok || len(f.PkgPath) > 0 {
+        return fmt.Errorf("doesn't exist %s field", name)
+    }
+    return nil
+}
+
+// passwordClass return the character class of r
+func passwordClass(r rune) string {
+    switch {
+    case unicode.IsLower(r):
+        return PasswordNoLower
+    case unicode.IsUpper(r):
+        return PasswordNoUpper
+    case unicode.IsDigit(r):
+        return PasswordNoDigit
+    }
+    return PasswordNoSymbol
+}
+
+// maxRepeated return the max number of the same characters in a row
+func maxRepeated(runes []rune) int {
+    max, n := 0, 0
+    for i, r := range runes {
+        if i > 0 && r == runes[i - 1] {
+            n++
+        } else {
+            n = 1
+        }
+        if n > max {
+            max = n
+        }
+    }
+    return max
+}
+
+// maxSequence return the max length of the ascending or descending sequential digits or letters,
+// such as 1234, abcd or 4321, the letters are case insensitive
+func maxSequence(runes []rune) int {
+    sequential := func(a, b rune) bool {
+        return ('0' <= a && a <= '9' && '0' <= b && b <= '9') || ('a' <= a && a <= 'z' && 'a' <= b && b <= 'z')
+    }
+    max, n, step := 0, 0, rune(0)
+    for i, r := range runes {
+        r = unicode.ToLower(r)
+        d := rune(0)
+        if i > 0 {
+            if prev := unicode.ToLower(runes[i - 1]); sequential(prev, r) {
+                d = r - prev
+            }
+        }
+        switch {
+        case (d == 1 || d == -1) && d == step:
+            n++
+        case d == 1 || d == -1:
+            n, step = 2, d
+        default:
+            n, step = 1, 0
+        }
+        if n > max {
+            max = n
+        }
+    }
+    return max
+}
+
+// Reason return the reason and the message if the password doesn't match the policy,
+// the reason is empty if it matches
+func (p PasswordPolicy) Reason(obj interface{}) (string, string) {
+    str, ok := obj.(string)
+    if !ok {
+        return "", ""
+    }
+    opts := p.Options
+    name := fetchFieldName(p.Key)
+    runes := []rune(str)
+
+    minLength := opts.MinLength
+    if minLength <= 0 {
+        minLength = passwordMinLength
+    }
+    if len(runes) < minLength {
+        return PasswordTooShort, fmt.Sprintf(MessageTmpls["PasswordMinLength"], name, minLength)
+    }
+    has := make(map[string]bool, 4)
+    for _, r := range runes {
+        has[passwordClass(r)] = true
+    }
+    for _, class := range opts.Require {
+        if !has[class] {
+            return class, fmt.Sprintf(MessageTmpls[passwordClassTmpls[class]], name)
+        }
+    }
+    if len(has) < opts.Classes {
+        return PasswordFewClasses, fmt.Sprintf(MessageTmpls["PasswordClasses"], name, opts.Classes)
+    }
+    if opts.Repeat > 0 && maxRepeated(runes) > opts.Repeat {
+        return PasswordRepeated, fmt.Sprintf(MessageTmpls["PasswordRepeat"], name, opts.Repeat)
+    }
+    if opts.Sequence > 0 && maxSequence(runes) >= opts.Sequence {
+        return PasswordSequence, fmt.Sprintf(MessageTmpls["PasswordSequence"], name, opts.Sequence)
+    }
+    if len(p.Username) > 0 && strings.Contains(strings.ToLower(str), strings.ToLower(p.Username)) {
+        return PasswordHasUsername, fmt.Sprintf(MessageTmpls["PasswordUsername"], name)
+    }
+    return "", ""
+}
+
+// IsSatisfied judge whether obj is valid
+func (p PasswordPolicy) IsSatisfied(obj interface{}) bool {
+    if _, ok := obj.(string); !ok {
+        return false
+    }
+    reason, _ := p.Reason(obj)
+    return len(reason) == 0
+}
+
+// DefaultMessage return the default PasswordPolicy error message
+func (p PasswordPolicy) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["PasswordPolicy"], fetchFieldName(p.Key))
+}
+
+// GetKey return the p.Key
+func (p PasswordPolicy) GetKey() string {
+    return p.Key
+}
+
+// GetLimitValue return the options
+func (p PasswordPolicy) GetLimitValue() interface{} {
+    return p.Options
+}
+
+// PasswordPolicy Test that the obj matches the password policy if type is string, see PasswordOptions
+func (v *Validation) PasswordPolicy(obj interface{}, options PasswordOptions, key string, errDesc string) *Result {
+    p := PasswordPolicy{Options: options, Key: key}
+    if len(options.UserField) > 0 {
+        p.Username = fmt.Sprint(v.fieldValue(options.UserField))
+    }
+    return v.apply(p, obj, errDesc)
+}
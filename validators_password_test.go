@@ -0,0 +1,127 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "testing"
+)
+
+func TestParsePasswordOptions(t *testing.T) {
+    tests := []struct {
+        options []string
+        ok      bool
+    }{
+        {nil, true},
+        {[]string{"min=10", "lower", "upper", "digit", "symbol"}, true},
+        {[]string{"classes=3", "repeat=1", "sequence=2", "user=Username"}, true},
+        {[]string{"min=0"}, false},
+        {[]string{"classes=5"}, false},
+        {[]string{"repeat=0"}, false},
+        {[]string{"sequence=1"}, false},
+        {[]string{"sequence=0"}, false},
+        {[]string{"min=-1"}, false},
+        {[]string{"min=a"}, false},
+        {[]string{"user="}, false},
+        {[]string{"special"}, false},
+    }
+    for _, test := range tests {
+        if _, err := ParsePasswordOptions(test.options...); (err == nil) != test.ok {
+            t.Errorf("ParsePasswordOptions(%v) error = %v, want ok %v", test.options, err, test.ok)
+        }
+    }
+}
+
+func TestPasswordReason(t *testing.T) {
+    tests := []struct {
+        password string
+        options  []string
+        username string
+        reason   string
+    }{
+        {"abcdefgh", nil, "", ""},
+        {"abcdefg", nil, "", PasswordTooShort},
+        {"abcdefghi", []string{"min=10"}, "", PasswordTooShort},
+        // the length is counted by characters
+        {"密码密码密码密码", nil, "", ""},
+        {"ABCDEFGH", []string{"lower"}, "", PasswordNoLower},
+        {"abcdefgh", []string{"upper"}, "", PasswordNoUpper},
+        {"abcdefgh", []string{"digit"}, "", PasswordNoDigit},
+        {"abcdefg1", []string{"digit", "symbol"}, "", PasswordNoSymbol},
+        {"abcdefg1", []string{"classes=3"}, "", PasswordFewClasses},
+        {"Abcdefg1", []string{"classes=3"}, "", ""},
+        {"abcccdef", []string{"repeat=2"}, "", PasswordRepeated},
+        {"abccdeff", []string{"repeat=2"}, "", ""},
+        {"xa1234yz", []string{"sequence=4"}, "", PasswordSequence},
+        {"xaDCBAyz", []string{"sequence=4"}, "", PasswordSequence},
+        {"xa123yzq", []string{"sequence=4"}, "", ""},
+        {"pw-Beego-2024", nil, "beego", PasswordHasUsername},
+        {"pw-2024-x", nil, "beego", ""},
+    }
+    for _, test := range tests {
+        opts, err := ParsePasswordOptions(test.options...)
+        if err != nil {
+            t.Fatal(err)
+        }
+        p := PasswordPolicy{Options: opts, Username: test.username, Key: "user.PasswordPolicy"}
+        if reason, _ := p.Reason(test.password); reason != test.reason {
+            t.Errorf("Reason(%s, %v) = %s, want %s", test.password, test.options, reason, test.reason)
+        }
+        if p.IsSatisfied(test.password) != (len(test.reason) == 0) {
+            t.Errorf("IsSatisfied(%s, %v) should be %v", test.password, test.options, len(test.reason) == 0)
+        }
+    }
+}
+
+func TestPasswordPolicyTag(t *testing.T) {
+    type user struct {
+        Username string
+        Password string `valid:"PasswordPolicy(min=8,digit,user=Username)"`
+    }
+    tests := []struct {
+        u      user
+        reason string
+    }{
+        {user{"beego", "secret-2024"}, ""},
+        {user{"beego", "secret-beego"}, PasswordNoDigit},
+        {user{"beego", "my-BEEGO-2024"}, PasswordHasUsername},
+    }
+    for _, test := range tests {
+        valid := Validation{}
+        valid.Valid(test.u)
+        reason := ""
+        if valid.HasErrors() {
+            reason = valid.Errors[0].Reason
+        }
+        if reason != test.reason {
+            t.Errorf("Valid(%v) reason = %s, want %s", test.u, reason, test.reason)
+        }
+    }
+
+    type missing struct {
+        Password string `valid:"PasswordPolicy(user=Username)"`
+    }
+    type unexported struct {
+        username string
+        Password string `valid:"PasswordPolicy(user=username)"`
+    }
+    for _, obj := range []interface{}{missing{}, unexported{}} {
+        if err := (&Validation{}).Valid(obj); err == nil {
+            t.Errorf("the invalid user field of %T should be reported by Valid", obj)
+        }
+    }
+    if _, err := NewSchema(map[string]string{"password": "PasswordPolicy(user=Username)"}); err == nil {
+        t.Error("the user field should not be used by Schema")
+    }
+}
@@ -0,0 +1,244 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    _ "embed"
+    "encoding/json"
+    "fmt"
+    "os"
+    "strings"
+    "sync"
+)
+
+// PhoneRegion the numbering plan of a region
+type PhoneRegion struct {
+    // the country calling code, such as 86
+    Code string `json:"code"`
+    // the trunk prefix of the national format, such as 0
+    Trunk string `json:"trunk,omitempty"`
+    // the min and max length of the national significant number
+    Min int `json:"min"`
+    Max int `json:"max"`
+    // the national significant number must start with one of the prefixes if it is not empty,
+    // such as the area codes of US and CA start with 2-9, it never starts with 0
+    Prefixes []string `json:"prefixes,omitempty"`
+    // the prefixes of the mobile numbers, only the numbers with the same length as the prefixes
+    // and start with 1 are checked, now it is only used by CN
+    Mobile []string `json:"mobile,omitempty"`
+}
+
+//go:embed data/phone_regions.json
+var phoneRegionsJSON []byte
+
+// key: ISO 3166 alpha-2 region code
+// value: the numbering plan
+var (
+    phoneRegions   map[string]*PhoneRegion
+    phoneRegionsMu sync.RWMutex
+)
+
+func init() {
+    if err := json.Unmarshal(phoneRegionsJSON, &phoneRegions); err != nil {
+        panic(err)
+    }
+}
+
+// LoadPhoneRegions Load the numbering plans from a json file, which has the same format as
+// data/phone_regions.json, the regions in the file replace the exists ones, such as add new
+// chinese mobile prefixes without code changes
+func LoadPhoneRegions(path string) error {
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return err
+    }
+    var regions map[string]*PhoneRegion
+    if err = json.Unmarshal(data, &regions); err != nil {
+        return err
+    }
+    for name, region := range regions {
+        if !isDigits(region.Code) || region.Min <= 0 || region.Max < region.Min {
+            return fmt.Errorf("invalid phone region %s", name)
+        }
+        for _, prefix := range region.Mobile {
+            if !isDigits(prefix) {
+                return fmt.Errorf("invalid mobile prefix %s of phone region %s", prefix, name)
+            }
+        }
+        for _, prefix := range region.Prefixes {
+            if !isDigits(prefix) || prefix[0] == '0' {
+                return fmt.Errorf("invalid prefix %s of phone region %s", prefix, name)
+            }
+        }
+    }
+    phoneRegionsMu.Lock()
+    for name, region := range regions {
+        phoneRegions[name] = region
+    }
+    phoneRegionsMu.Unlock()
+    return nil
+}
+
+// AddMobilePrefixes Add the mobile prefixes to the region, such as AddMobilePrefixes("CN", "194")
+func AddMobilePrefixes(region string, prefixes ...string) error {
+    phoneRegionsMu.Lock()
+    defer phoneRegionsMu.Unlock()
+    r, ok := phoneRegions[region]
+    if !ok {
+        return fmt.Errorf("doesn't exist %s phone region", region)
+    }
+    for _, prefix := range prefixes {
+        if !isDigits(prefix) {
+            return fmt.Errorf("invalid mobile prefix %s", prefix)
+        }
+    }
+    // copy on write, the region may be read by validations
+    c := *r
+    c.Mobile = append(append([]string{}, r.Mobile...), prefixes...)
+    phoneRegions[region] = &c
+    return nil
+}
+
+// getPhoneRegion return the numbering plan of the region, it is nil if the region doesn't exist
+func getPhoneRegion(region string) *PhoneRegion {
+    phoneRegionsMu.RLock()
+    defer phoneRegionsMu.RUnlock()
+    return phoneRegions[region]
+}
+
+// PhoneRegions the region codes of Phone, such as US and CA of Phone(US,CA)
+type PhoneRegions []string
+
+// ParsePhoneRegions check the regions are in the phone numbering table
+func ParsePhoneRegions(regions ...string) (PhoneRegions, error) {
+    if len(regions) == 0 {
+        return nil, fmt.Errorf("Phone require at least 1 regions")
+    }
+    for _, region := range regions {
+        if getPhoneRegion(region) == nil {
+            return nil, fmt.Errorf("doesn't exist %s phone region", region)
+        }
+    }
+    return PhoneRegions(regions), nil
+}
+
+// stripPhone remove the spaces, dashes, dots and parentheses in the phone number
+func stripPhone(s string) string {
+    return strings.Map(func(r rune) rune {
+        switch r {
+        case ' ', '-', '.', '(', ')':
+            return -1
+        }
+        return r
+    }, s)
+}
+
+// hasPrefix judge whether s start with one of the prefixes
+func hasPrefix(s string, prefixes []string) bool {
+    for _, prefix := range prefixes {
+        if strings.HasPrefix(s, prefix) {
+            return true
+        }
+    }
+    return false
+}
+
+// isMobileNumber judge whether the national significant number nsn start with one of the mobile prefixes
+func (r *PhoneRegion) isMobileNumber(nsn string) bool {
+    return hasPrefix(nsn, r.Mobile)
+}
+
+// match judge whether number is a phone number of the region, in international format such as
+// +86 138 0013 8000 or national format such as 010-12345678
+func (r *PhoneRegion) match(number string) bool {
+    number = stripPhone(number)
+    var nsn string
+    switch {
+    case strings.HasPrefix(number, "+"):
+        if !strings.HasPrefix(number[1:], r.Code) {
+            return false
+        }
+        nsn = number[1 + len(r.Code):]
+    case len(r.Trunk) > 0 && strings.HasPrefix(number, r.Trunk):
+        nsn = number[len(r.Trunk):]
+    default:
+        nsn = number
+    }
+    if !isDigits(nsn) || len(nsn) < r.Min || len(nsn) > r.Max || nsn[0] == '0' {
+        return false
+    }
+    if len(r.Prefixes) > 0 && !hasPrefix(nsn, r.Prefixes) {
+        return false
+    }
+    if len(r.Mobile) > 0 && nsn[0] == '1' && len(nsn) == r.Max {
+        return r.isMobileNumber(nsn)
+    }
+    return true
+}
+
+// isCNMobile judge whether s is a chinese mobile number, the +86 or 86 prefix is allowed
+func isCNMobile(s string) bool {
+    if strings.HasPrefix(s, "+86") {
+        s = s[3:]
+    } else if strings.HasPrefix(s, "86") && len(s) == 13 {
+        s = s[2:]
+    }
+    r := getPhoneRegion("CN")
+    return len(s) == 11 && isDigits(s) && r != nil && r.isMobileNumber(s)
+}
+
+// the length of the digits of E.164, the country code has at least 1 digit,
+// and the subscriber number has at least 1 digit
+const (
+    e164MinLength = 2
+    e164MaxLength = 15
+)
+
+// E164 check the string is a phone number in E.164 format, such as +8613800138000,
+// the + is followed by at most 15 digits which start with the country code, the numbers can be short,
+// such as +6834002 of Niue
+type E164 struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (e E164) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    if !ok || !strings.HasPrefix(str, "+") {
+        return false
+    }
+    str = str[1:]
+    return isDigits(str) && str[0] != '0' && len(str) >= e164MinLength && len(str) <= e164MaxLength
+}
+
+// DefaultMessage return the default E164 error message
+func (e E164) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["E164"], fetchFieldName(e.Key))
+}
+
+// GetKey return the e.Key
+func (e E164) GetKey() string {
+    return e.Key
+}
+
+// GetLimitValue return the limit value
+func (e E164) GetLimitValue() interface{} {
+    return nil
+}
+
+// E164 Test that the obj is phone number in E.164 format if type is string
+func (v *Validation) E164(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(E164{key}, obj, errDesc)
+}
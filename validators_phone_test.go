@@ -0,0 +1,143 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "os"
+    "path/filepath"
+    "testing"
+)
+
+func TestE164(t *testing.T) {
+    tests := []struct {
+        obj  interface{}
+        want bool
+    }{
+        {"+8613800138000", true},
+        {"+14155552671", true},
+        // the short numbers, such as Niue
+        {"+6834002", true},
+        {"+12", true},
+        {"+123456789012345", true},
+        {"+1234567890123456", false},
+        {"+1", false},
+        {"+04155552671", false},
+        {"14155552671", false},
+        {"+1 415 555 2671", false},
+        {"+", false},
+        {14155552671, false},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        if valid.E164(test.obj, "phone.E164", "").Ok != test.want {
+            t.Errorf("E164(%v) should be %v", test.obj, test.want)
+        }
+    }
+}
+
+func TestPhoneInRegions(t *testing.T) {
+    tests := []struct {
+        obj     string
+        regions PhoneRegions
+        want    bool
+    }{
+        {"+1 (415) 555-2671", PhoneRegions{"US"}, true},
+        {"1-415-555-2671", PhoneRegions{"US"}, true},
+        {"4155552671", PhoneRegions{"US", "CA"}, true},
+        // the area codes of NANP start with 2-9
+        {"+1 115 555 2671", PhoneRegions{"US"}, false},
+        {"0155552671", PhoneRegions{"US"}, false},
+        {"+44 20 7946 0958", PhoneRegions{"US"}, false},
+        {"020 7946 0958", PhoneRegions{"US", "GB"}, true},
+        {"+44 7700 900123", PhoneRegions{"GB"}, true},
+        // the numbers of GB don't start with 4 or 6
+        {"+44 4700 900123", PhoneRegions{"GB"}, false},
+        {"+86 138 0013 8000", PhoneRegions{"CN"}, true},
+        {"010-12345678", PhoneRegions{"CN"}, true},
+        {"+86 120 1234 5678", PhoneRegions{"CN"}, false},
+        {"+86 012 3456 7890", PhoneRegions{"CN"}, false},
+        {"+65 6123 4567", PhoneRegions{"SG"}, true},
+        {"+65 1234 5678", PhoneRegions{"SG"}, false},
+        {"+7 912 345 67 89", PhoneRegions{"RU"}, true},
+        {"8 912 345 67 89", PhoneRegions{"RU"}, true},
+        {"+7 112 345 67 89", PhoneRegions{"RU"}, false},
+        {"+61 4 1234 5678", PhoneRegions{"AU"}, true},
+        {"+61 6 1234 5678", PhoneRegions{"AU"}, false},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        if phoneInRegions(&valid, test.obj, test.regions, "phone.Phone", "").Ok != test.want {
+            t.Errorf("Phone(%s) in %v should be %v", test.obj, test.regions, test.want)
+        }
+    }
+}
+
+func TestMobile(t *testing.T) {
+    tests := []struct {
+        obj  interface{}
+        want bool
+    }{
+        {"13800138000", true},
+        {"19912345678", true},
+        {"+8613800138000", true},
+        {"8613800138000", true},
+        {"12012345678", false},
+        {"1380013800", false},
+        {13800138000, false},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        if valid.Mobile(test.obj, "phone.Mobile", "").Ok != test.want {
+            t.Errorf("Mobile(%v) should be %v", test.obj, test.want)
+        }
+    }
+}
+
+func TestLoadPhoneRegions(t *testing.T) {
+    dir := t.TempDir()
+    tests := []struct {
+        data string
+        ok   bool
+    }{
+        {`{"XA": {"code": "999", "min": 5, "max": 6, "prefixes": ["5", "7"]}}`, true},
+        {`{"XB": {"code": "99a", "min": 5, "max": 6}}`, false},
+        {`{"XB": {"code": "998", "min": 6, "max": 5}}`, false},
+        {`{"XB": {"code": "998", "min": 5, "max": 6, "mobile": ["1a"]}}`, false},
+        {`{"XB": {"code": "998", "min": 5, "max": 6, "mobile": [""]}}`, false},
+        {`{"XB": {"code": "998", "min": 5, "max": 6, "prefixes": ["0"]}}`, false},
+        {`{"XB": {"code": "998", "min": 5, "max": 6, "prefixes": [""]}}`, false},
+        {`not json`, false},
+    }
+    for i, test := range tests {
+        path := filepath.Join(dir, "regions.json")
+        if err := os.WriteFile(path, []byte(test.data), 0644); err != nil {
+            t.Fatal(err)
+        }
+        if err := LoadPhoneRegions(path); (err == nil) != test.ok {
+            t.Errorf("LoadPhoneRegions(%d) error = %v, want ok %v", i, err, test.ok)
+        }
+    }
+    // the invalid file doesn't replace any region
+    if getPhoneRegion("XB") != nil {
+        t.Error("the invalid region should not be loaded")
+    }
+
+    valid := Validation{}
+    for obj, want := range map[string]bool{"+999 512345": true, "71234": true, "+999 612345": false, "+999 5123": false} {
+        if phoneInRegions(&valid, obj, PhoneRegions{"XA"}, "phone.Phone", "").Ok != want {
+            t.Errorf("Phone(%s) in XA should be %v", obj, want)
+        }
+    }
+}
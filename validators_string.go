@@ -0,0 +1,410 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "fmt"
+    "strings"
+    "unicode"
+)
+
+// Contains Requires a string to contain the given substring
+type Contains struct {
+    Substr string
+    Key    string
+}
+
+// IsSatisfied judge whether obj is valid
+func (c Contains) IsSatisfied(obj interface{}) bool {
+    if str, ok := obj.(string); ok {
+        return strings.Contains(str, c.Substr)
+    }
+    return false
+}
+
+// DefaultMessage return the default Contains error message
+func (c Contains) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["Contains"], fetchFieldName(c.Key), c.Substr)
+}
+
+// GetKey return the c.Key
+func (c Contains) GetKey() string {
+    return c.Key
+}
+
+// GetLimitValue return the limit value
+func (c Contains) GetLimitValue() interface{} {
+    return c.Substr
+}
+
+// ContainsAny Requires a string to contain at least one of the given characters
+type ContainsAny struct {
+    Chars string
+    Key   string
+}
+
+// IsSatisfied judge whether obj is valid
+func (c ContainsAny) IsSatisfied(obj interface{}) bool {
+    if str, ok := obj.(string); ok {
+        return strings.ContainsAny(str, c.Chars)
+    }
+    return false
+}
+
+// DefaultMessage return the default ContainsAny error message
+func (c ContainsAny) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["ContainsAny"], fetchFieldName(c.Key), c.Chars)
+}
+
+// GetKey return the c.Key
+func (c ContainsAny) GetKey() string {
+    return c.Key
+}
+
+// GetLimitValue return the limit value
+func (c ContainsAny) GetLimitValue() interface{} {
+    return c.Chars
+}
+
+// Excludes Requires a string not to contain the given substring
+type Excludes struct {
+    Substr string
+    Key    string
+}
+
+// IsSatisfied judge whether obj is valid
+func (e Excludes) IsSatisfied(obj interface{}) bool {
+    if str, ok := obj.(string); ok {
+        return !strings.Contains(str, e.Substr)
+    }
+    return false
+}
+
+// DefaultMessage return the default Excludes error message
+func (e Excludes) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["Excludes"], fetchFieldName(e.Key), e.Substr)
+}
+
+// GetKey return the e.Key
+func (e Excludes) GetKey() string {
+    return e.Key
+}
+
+// GetLimitValue return the limit value
+func (e Excludes) GetLimitValue() interface{} {
+    return e.Substr
+}
+
+// Char a single character parameter of the valid tag, such as the x of ExcludesRune(x),
+// it is a distinct type so the int32 parameters and default values are parsed as numbers
+type Char rune
+
+// ExcludesRune Requires a string not to contain the given rune
+type ExcludesRune struct {
+    Rune rune
+    Key  string
+}
+
+// IsSatisfied judge whether obj is valid
+func (e ExcludesRune) IsSatisfied(obj interface{}) bool {
+    if str, ok := obj.(string); ok {
+        return !strings.ContainsRune(str, e.Rune)
+    }
+    return false
+}
+
+// DefaultMessage return the default ExcludesRune error message
+func (e ExcludesRune) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["ExcludesRune"], fetchFieldName(e.Key), string(e.Rune))
+}
+
+// GetKey return the e.Key
+func (e ExcludesRune) GetKey() string {
+    return e.Key
+}
+
+// GetLimitValue return the limit value
+func (e ExcludesRune) GetLimitValue() interface{} {
+    return string(e.Rune)
+}
+
+// StartsWith Requires a string to start with the given prefix
+type StartsWith struct {
+    Prefix string
+    Key    string
+}
+
+// IsSatisfied judge whether obj is valid
+func (s StartsWith) IsSatisfied(obj interface{}) bool {
+    if str, ok := obj.(string); ok {
+        return strings.HasPrefix(str, s.Prefix)
+    }
+    return false
+}
+
+// DefaultMessage return the default StartsWith error message
+func (s StartsWith) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["StartsWith"], fetchFieldName(s.Key), s.Prefix)
+}
+
+// GetKey return the s.Key
+func (s StartsWith) GetKey() string {
+    return s.Key
+}
+
+// GetLimitValue return the limit value
+func (s StartsWith) GetLimitValue() interface{} {
+    return s.Prefix
+}
+
+// EndsWith Requires a string to end with the given suffix
+type EndsWith struct {
+    Suffix string
+    Key    string
+}
+
+// IsSatisfied judge whether obj is valid
+func (e EndsWith) IsSatisfied(obj interface{}) bool {
+    if str, ok := obj.(string); ok {
+        return strings.HasSuffix(str, e.Suffix)
+    }
+    return false
+}
+
+// DefaultMessage return the default EndsWith error message
+func (e EndsWith) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["EndsWith"], fetchFieldName(e.Key), e.Suffix)
+}
+
+// GetKey return the e.Key
+func (e EndsWith) GetKey() string {
+    return e.Key
+}
+
+// GetLimitValue return the limit value
+func (e EndsWith) GetLimitValue() interface{} {
+    return e.Suffix
+}
+
+// allRunes judge whether obj is a string and every rune of it satisfy f
+func allRunes(obj interface{}, f func(r rune) bool) bool {
+    str, ok := obj.(string)
+    if !ok {
+        return false
+    }
+    for _, r := range str {
+        if !f(r) {
+            return false
+        }
+    }
+    return true
+}
+
+// isDigits judge whether s is not empty and only contains 0-9
+func isDigits(s string) bool {
+    return len(s) > 0 && allRunes(s, func(r rune) bool {
+        return '0' <= r && r <= '9'
+    })
+}
+
+// ASCII check the string only contains ascii characters
+type ASCII struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (a ASCII) IsSatisfied(obj interface{}) bool {
+    return allRunes(obj, func(r rune) bool {
+        return r <= unicode.MaxASCII
+    })
+}
+
+// DefaultMessage return the default ASCII error message
+func (a ASCII) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["ASCII"], fetchFieldName(a.Key))
+}
+
+// GetKey return the a.Key
+func (a ASCII) GetKey() string {
+    return a.Key
+}
+
+// GetLimitValue return the limit value
+func (a ASCII) GetLimitValue() interface{} {
+    return nil
+}
+
+// PrintableASCII check the string only contains printable ascii characters
+type PrintableASCII struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (p PrintableASCII) IsSatisfied(obj interface{}) bool {
+    return allRunes(obj, func(r rune) bool {
+        return ' ' <= r && r <= '~'
+    })
+}
+
+// DefaultMessage return the default PrintableASCII error message
+func (p PrintableASCII) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["PrintableASCII"], fetchFieldName(p.Key))
+}
+
+// GetKey return the p.Key
+func (p PrintableASCII) GetKey() string {
+    return p.Key
+}
+
+// GetLimitValue return the limit value
+func (p PrintableASCII) GetLimitValue() interface{} {
+    return nil
+}
+
+// Lowercase check the string has no upper case letter
+type Lowercase struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (l Lowercase) IsSatisfied(obj interface{}) bool {
+    return allRunes(obj, func(r rune) bool {
+        return !unicode.IsUpper(r)
+    })
+}
+
+// DefaultMessage return the default Lowercase error message
+func (l Lowercase) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["Lowercase"], fetchFieldName(l.Key))
+}
+
+// GetKey return the l.Key
+func (l Lowercase) GetKey() string {
+    return l.Key
+}
+
+// GetLimitValue return the limit value
+func (l Lowercase) GetLimitValue() interface{} {
+    return nil
+}
+
+// Uppercase check the string has no lower case letter
+type Uppercase struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (u Uppercase) IsSatisfied(obj interface{}) bool {
+    return allRunes(obj, func(r rune) bool {
+        return !unicode.IsLower(r)
+    })
+}
+
+// DefaultMessage return the default Uppercase error message
+func (u Uppercase) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["Uppercase"], fetchFieldName(u.Key))
+}
+
+// GetKey return the u.Key
+func (u Uppercase) GetKey() string {
+    return u.Key
+}
+
+// GetLimitValue return the limit value
+func (u Uppercase) GetLimitValue() interface{} {
+    return nil
+}
+
+// NoWhitespace check the string has no white space
+type NoWhitespace struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (n NoWhitespace) IsSatisfied(obj interface{}) bool {
+    return allRunes(obj, func(r rune) bool {
+        return !unicode.IsSpace(r)
+    })
+}
+
+// DefaultMessage return the default NoWhitespace error message
+func (n NoWhitespace) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["NoWhitespace"], fetchFieldName(n.Key))
+}
+
+// GetKey return the n.Key
+func (n NoWhitespace) GetKey() string {
+    return n.Key
+}
+
+// GetLimitValue return the limit value
+func (n NoWhitespace) GetLimitValue() interface{} {
+    return nil
+}
+
+// Contains Test that the obj contains substr if type is string
+func (v *Validation) Contains(obj interface{}, substr string, key string, errDesc string) *Result {
+    return v.apply(Contains{substr, key}, obj, errDesc)
+}
+
+// ContainsAny Test that the obj contains any of the chars if type is string
+func (v *Validation) ContainsAny(obj interface{}, chars string, key string, errDesc string) *Result {
+    return v.apply(ContainsAny{chars, key}, obj, errDesc)
+}
+
+// Excludes Test that the obj doesn't contain substr if type is string
+func (v *Validation) Excludes(obj interface{}, substr string, key string, errDesc string) *Result {
+    return v.apply(Excludes{substr, key}, obj, errDesc)
+}
+
+// ExcludesRune Test that the obj doesn't contain the rune if type is string
+func (v *Validation) ExcludesRune(obj interface{}, r Char, key string, errDesc string) *Result {
+    return v.apply(ExcludesRune{rune(r), key}, obj, errDesc)
+}
+
+// StartsWith Test that the obj starts with prefix if type is string
+func (v *Validation) StartsWith(obj interface{}, prefix string, key string, errDesc string) *Result {
+    return v.apply(StartsWith{prefix, key}, obj, errDesc)
+}
+
+// EndsWith Test that the obj ends with suffix if type is string
+func (v *Validation) EndsWith(obj interface{}, suffix string, key string, errDesc string) *Result {
+    return v.apply(EndsWith{suffix, key}, obj, errDesc)
+}
+
+// ASCII Test that the obj only contains ascii characters if type is string
+func (v *Validation) ASCII(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(ASCII{key}, obj, errDesc)
+}
+
+// PrintableASCII Test that the obj only contains printable ascii characters if type is string
+func (v *Validation) PrintableASCII(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(PrintableASCII{key}, obj, errDesc)
+}
+
+// Lowercase Test that the obj has no upper case letter if type is string
+func (v *Validation) Lowercase(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(Lowercase{key}, obj, errDesc)
+}
+
+// Uppercase Test that the obj has no lower case letter if type is string
+func (v *Validation) Uppercase(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(Uppercase{key}, obj, errDesc)
+}
+
+// NoWhitespace Test that the obj has no white space if type is string
+func (v *Validation) NoWhitespace(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(NoWhitespace{key}, obj, errDesc)
+}
@@ -0,0 +1,152 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "testing"
+)
+
+func TestStrings(t *testing.T) {
+    tests := []struct {
+        name  string
+        obj   interface{}
+        param string
+        want  bool
+    }{
+        {"Contains", "hello world", "lo w", true},
+        {"Contains", "hello", "world", false},
+        {"Contains", 123, "1", false},
+        {"ContainsAny", "p@ss", "!@#", true},
+        {"ContainsAny", "pass", "!@#", false},
+        {"Excludes", "hello", "admin", true},
+        {"Excludes", "superadmin", "admin", false},
+        {"Excludes", 1, "admin", false},
+        {"StartsWith", "https://a", "https://", true},
+        {"StartsWith", "http://a", "https://", false},
+        {"EndsWith", "a.png", ".png", true},
+        {"EndsWith", "a.jpg", ".png", false},
+        {"ASCII", "abc 123 ~\n", "", true},
+        {"ASCII", "", "", true},
+        {"ASCII", "abc中", "", false},
+        {"PrintableASCII", "abc 123 ~", "", true},
+        {"PrintableASCII", "abc\n", "", false},
+        {"PrintableASCII", "é", "", false},
+        {"Lowercase", "abc-123 中", "", true},
+        {"Lowercase", "aBc", "", false},
+        {"Lowercase", "ÀB", "", false},
+        {"Uppercase", "ABC-123", "", true},
+        {"Uppercase", "AbC", "", false},
+        {"NoWhitespace", "abc", "", true},
+        {"NoWhitespace", "a b", "", false},
+        {"NoWhitespace", "a\tb", "", false},
+        {"NoWhitespace", "a　b", "", false},
+        {"NoWhitespace", 1, "", false},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        var r *Result
+        key := "s." + test.name
+        switch test.name {
+        case "Contains":
+            r = valid.Contains(test.obj, test.param, key, "")
+        case "ContainsAny":
+            r = valid.ContainsAny(test.obj, test.param, key, "")
+        case "Excludes":
+            r = valid.Excludes(test.obj, test.param, key, "")
+        case "StartsWith":
+            r = valid.StartsWith(test.obj, test.param, key, "")
+        case "EndsWith":
+            r = valid.EndsWith(test.obj, test.param, key, "")
+        case "ASCII":
+            r = valid.ASCII(test.obj, key, "")
+        case "PrintableASCII":
+            r = valid.PrintableASCII(test.obj, key, "")
+        case "Lowercase":
+            r = valid.Lowercase(test.obj, key, "")
+        case "Uppercase":
+            r = valid.Uppercase(test.obj, key, "")
+        case "NoWhitespace":
+            r = valid.NoWhitespace(test.obj, key, "")
+        }
+        if r.Ok != test.want {
+            t.Errorf("%s(%v, %s) should be %v", test.name, test.obj, test.param, test.want)
+        }
+    }
+}
+
+func TestExcludesRune(t *testing.T) {
+    type user struct {
+        Name string `valid:"ExcludesRune(@)"`
+        Code string `valid:"ExcludesRune(中)"`
+    }
+    tests := []struct {
+        u   user
+        key string
+    }{
+        {user{"beego", "abc"}, ""},
+        {user{"be@go", "abc"}, "Name.ExcludesRune"},
+        {user{"beego", "中文"}, "Code.ExcludesRune"},
+    }
+    for _, test := range tests {
+        valid := Validation{}
+        if err := valid.Valid(test.u); err != nil && !valid.HasErrors() {
+            t.Fatal(err)
+        }
+        key := ""
+        if valid.HasErrors() {
+            key = valid.Errors[0].Key
+        }
+        if key != test.key {
+            t.Errorf("Valid(%v) error key = %s, want %s", test.u, key, test.key)
+        }
+    }
+}
+
+func TestSetLocale(t *testing.T) {
+    t.Cleanup(func() {
+        SetLocale("zh")
+    })
+    // every validator has the english message
+    for name := range MessageTmpls {
+        if _, ok := MessageTmplsEn[name]; !ok {
+            t.Errorf("the english message of %s doesn't exist", name)
+        }
+    }
+    for name := range MessageTmplsEn {
+        if _, ok := MessageTmpls[name]; !ok {
+            t.Errorf("the english message of %s has no default message", name)
+        }
+    }
+
+    if err := SetLocale("fr"); err == nil {
+        t.Error("SetLocale should return the error of the unknown locale")
+    }
+    tests := []struct {
+        locale  string
+        message string
+    }{
+        {"en", "Name must contain beego"},
+        {"zh", "Name 必须包含 beego"},
+    }
+    for _, test := range tests {
+        if err := SetLocale(test.locale); err != nil {
+            t.Fatal(err)
+        }
+        valid := Validation{}
+        if r := valid.Contains("go", "beego", "Name.Contains", ""); r.Error == nil || r.Error.Message != test.message {
+            t.Errorf("the message of %s = %v, want %s", test.locale, r.Error, test.message)
+        }
+    }
+}
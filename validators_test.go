@@ -0,0 +1,64 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "regexp"
+    "testing"
+)
+
+// TestCompatibleLayouts the validators can be created by the unkeyed composite literals as before,
+// the Regexp of the embedded Match is used if it is set
+func TestCompatibleLayouts(t *testing.T) {
+    digits := Match{Regexp: regexp.MustCompile("^[0-9]+$")}
+    tests := []struct {
+        chk  Validator
+        obj  interface{}
+        want bool
+    }{
+        {Email{digits, "a.Email"}, "123", true},
+        {Email{digits, "a.Email"}, "a@b.cn", false},
+        {Email{Match{}, "a.Email"}, "a@b.cn", true},
+        {Email{Match{}, "a.Email"}, "123", false},
+        {IP{digits, "a.IP"}, "123", true},
+        {IP{Match{}, "a.IP"}, "::1", true},
+        {IP{Match{}, "a.IP"}, "123", false},
+        {Base64{digits, "a.Base64"}, "123", true},
+        {Base64{Match{}, "a.Base64"}, "aGVsbG8=", true},
+        {Base64{Match{}, "a.Base64"}, "aGVsbG8", false},
+        {MinSize{2, "a.MinSize"}, "中文", true},
+        {MinSize{3, "a.MinSize"}, "中文", false},
+        {MaxSize{2, "a.MaxSize"}, "中文", true},
+        {MaxSize{1, "a.MaxSize"}, []int{1, 2}, false},
+        {Length{2, "a.Length"}, "中文", true},
+        {LengthMode{6, SizeBytes, "a.Length"}, "中文", true},
+        {MaxSizeMode{3, SizeWidth, "a.MaxSize"}, "中文", false},
+        {Mobile{digits, "a.Mobile"}, "123", true},
+        {Mobile{Match{}, "a.Mobile"}, "13800138000", true},
+        {Mobile{Match{}, "a.Mobile"}, "123", false},
+        {Phone{Mobile{}, Tel{Match{Regexp: telPattern}, ""}, "a.Phone"}, "010-12345678", true},
+        {Phone{Mobile{}, Tel{Match{Regexp: telPattern}, ""}, "a.Phone"}, "13800138000", true},
+        {Phone{Mobile{digits, ""}, Tel{Match{Regexp: telPattern}, ""}, "a.Phone"}, "1", true},
+        {Phone{Mobile{}, Tel{Match{Regexp: telPattern}, ""}, "a.Phone"}, "1", false},
+    }
+    for _, test := range tests {
+        if test.chk.IsSatisfied(test.obj) != test.want {
+            t.Errorf("%#v.IsSatisfied(%v) should be %v", test.chk, test.obj, test.want)
+        }
+    }
+    if (Email{digits, "a.Email"}).Match.Regexp != digits.Regexp {
+        t.Error("the embedded Match of Email should be kept")
+    }
+}
@@ -0,0 +1,365 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "fmt"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+)
+
+var (
+    clock   = time.Now
+    clockMu sync.RWMutex
+)
+
+// SetClock Set the function which return the current time for the relative time expressions
+// and the age validators, such as a fixed time in tests, nil to reset to time.Now
+func SetClock(now func() time.Time) {
+    if now == nil {
+        now = time.Now
+    }
+    clockMu.Lock()
+    clock = now
+    clockMu.Unlock()
+}
+
+func now() time.Time {
+    clockMu.RLock()
+    defer clockMu.RUnlock()
+    return clock()
+}
+
+// TimeExpr a time expression, which can be an absolute time such as 2006-01-02, 2006-01-02 15:04:05
+// or RFC3339, or a relative time based on now or today, such as now, today, now+30d, today-18y
+// the units of the offset are y (year), mo (month), w (week), d (day), h (hour), m (minute), s (second)
+type TimeExpr struct {
+    expr   string
+    base   string
+    abs    time.Time
+    years  int
+    months int
+    days   int
+    dur    time.Duration
+}
+
+// ParseTimeExpr parse the time expression, see TimeExpr
+func ParseTimeExpr(s string) (e TimeExpr, err error) {
+    e.expr = strings.TrimSpace(s)
+    for _, base := range []string{"now", "today"} {
+        if !strings.HasPrefix(e.expr, base) {
+            continue
+        }
+        e.base = base
+        offset := strings.TrimSpace(e.expr[len(base):])
+        if len(offset) == 0 {
+            return
+        }
+        if offset[0] != '+' && offset[0] != '-' {
+            break
+        }
+        sign := 1
+        if offset[0] == '-' {
+            sign = -1
+        }
+        offset = strings.TrimSpace(offset[1:])
+        end := 0
+        for end < len(offset) && '0' <= offset[end] && offset[end] <= '9' {
+            end++
+        }
+        var n int
+        if n, err = strconv.Atoi(offset[:end]); err != nil {
+            break
+        }
+        n *= sign
+        switch offset[end:] {
+        case "y":
+            e.years = n
+        case "mo":
+            e.months = n
+        case "w":
+            e.days = n * 7
+        case "d":
+            e.days = n
+        case "h":
+            e.dur = time.Duration(n) * time.Hour
+        case "m":
+            e.dur = time.Duration(n) * time.Minute
+        case "s":
+            e.dur = time.Duration(n) * time.Second
+        default:
+            err = fmt.Errorf("invalid time expression %s", s)
+        }
+        return
+    }
+    var ok bool
+    if e.abs, ok = parseTime(e.expr); !ok {
+        err = fmt.Errorf("invalid time expression %s", s)
+    }
+    return
+}
+
+// Time return the time of the expression, the relative time is based on the clock set by SetClock
+func (e TimeExpr) Time() time.Time {
+    switch e.base {
+    case "now":
+        return now().AddDate(e.years, e.months, e.days).Add(e.dur)
+    case "today":
+        n := now()
+        today := time.Date(n.Year(), n.Month(), n.Day(), 0, 0, 0, 0, n.Location())
+        return today.AddDate(e.years, e.months, e.days).Add(e.dur)
+    }
+    return e.abs
+}
+
+// String return the expression
+func (e TimeExpr) String() string {
+    return e.expr
+}
+
+// parseTime parse the string by the layouts supported by the default tag
+func parseTime(s string) (time.Time, bool) {
+    for _, layout := range timeLayouts {
+        if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+            return t, true
+        }
+    }
+    return time.Time{}, false
+}
+
+// toTime convert obj to time, obj can be a time.Time or a string in the layouts supported by the default tag
+func toTime(obj interface{}) (time.Time, bool) {
+    switch t := obj.(type) {
+    case time.Time:
+        return t, !t.IsZero()
+    case *time.Time:
+        if t == nil {
+            return time.Time{}, false
+        }
+        return *t, !t.IsZero()
+    case string:
+        return parseTime(t)
+    }
+    return time.Time{}, false
+}
+
+// ageOf return the full years from birthday to now
+func ageOf(birthday time.Time) int {
+    n := now().In(birthday.Location())
+    years := n.Year() - birthday.Year()
+    if n.Month() < birthday.Month() || (n.Month() == birthday.Month() && n.Day() < birthday.Day()) {
+        years--
+    }
+    return years
+}
+
+// DateFormat check the string is a time in the layout, such as DateFormat(2006-01-02)
+type DateFormat struct {
+    Layout string
+    Key    string
+}
+
+// IsSatisfied judge whether obj is valid
+func (d DateFormat) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    if !ok {
+        return false
+    }
+    _, err := time.Parse(d.Layout, str)
+    return err == nil
+}
+
+// DefaultMessage return the default DateFormat error message
+func (d DateFormat) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["DateFormat"], fetchFieldName(d.Key), d.Layout)
+}
+
+// GetKey return the d.Key
+func (d DateFormat) GetKey() string {
+    return d.Key
+}
+
+// GetLimitValue return the limit value
+func (d DateFormat) GetLimitValue() interface{} {
+    return d.Layout
+}
+
+// Before check the time is before the time expression
+type Before struct {
+    Expr TimeExpr
+    Key  string
+}
+
+// IsSatisfied judge whether obj is valid
+func (b Before) IsSatisfied(obj interface{}) bool {
+    t, ok := toTime(obj)
+    return ok && t.Before(b.Expr.Time())
+}
+
+// DefaultMessage return the default Before error message
+func (b Before) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["Before"], fetchFieldName(b.Key), b.Expr)
+}
+
+// GetKey return the b.Key
+func (b Before) GetKey() string {
+    return b.Key
+}
+
+// GetLimitValue return the limit time
+func (b Before) GetLimitValue() interface{} {
+    return b.Expr.Time()
+}
+
+// After check the time is after the time expression
+type After struct {
+    Expr TimeExpr
+    Key  string
+}
+
+// IsSatisfied judge whether obj is valid
+func (a After) IsSatisfied(obj interface{}) bool {
+    t, ok := toTime(obj)
+    return ok && t.After(a.Expr.Time())
+}
+
+// DefaultMessage return the default After error message
+func (a After) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["After"], fetchFieldName(a.Key), a.Expr)
+}
+
+// GetKey return the a.Key
+func (a After) GetKey() string {
+    return a.Key
+}
+
+// GetLimitValue return the limit time
+func (a After) GetLimitValue() interface{} {
+    return a.Expr.Time()
+}
+
+// Between check the time is between the time expressions inclusive
+type Between struct {
+    From TimeExpr
+    To   TimeExpr
+    Key  string
+}
+
+// IsSatisfied judge whether obj is valid
+func (b Between) IsSatisfied(obj interface{}) bool {
+    t, ok := toTime(obj)
+    return ok && !t.Before(b.From.Time()) && !t.After(b.To.Time())
+}
+
+// DefaultMessage return the default Between error message
+func (b Between) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["Between"], fetchFieldName(b.Key), b.From, b.To)
+}
+
+// GetKey return the b.Key
+func (b Between) GetKey() string {
+    return b.Key
+}
+
+// GetLimitValue return the limit times
+func (b Between) GetLimitValue() interface{} {
+    return []time.Time{b.From.Time(), b.To.Time()}
+}
+
+// MinAge check the age of the birthday is at least Age years
+type MinAge struct {
+    Age int
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (m MinAge) IsSatisfied(obj interface{}) bool {
+    t, ok := toTime(obj)
+    return ok && ageOf(t) >= m.Age
+}
+
+// DefaultMessage return the default MinAge error message
+func (m MinAge) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["MinAge"], fetchFieldName(m.Key), m.Age)
+}
+
+// GetKey return the m.Key
+func (m MinAge) GetKey() string {
+    return m.Key
+}
+
+// GetLimitValue return the limit value
+func (m MinAge) GetLimitValue() interface{} {
+    return m.Age
+}
+
+// MaxAge check the age of the birthday is at most Age years
+type MaxAge struct {
+    Age int
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (m MaxAge) IsSatisfied(obj interface{}) bool {
+    t, ok := toTime(obj)
+    return ok && ageOf(t) <= m.Age
+}
+
+// DefaultMessage return the default MaxAge error message
+func (m MaxAge) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["MaxAge"], fetchFieldName(m.Key), m.Age)
+}
+
+// GetKey return the m.Key
+func (m MaxAge) GetKey() string {
+    return m.Key
+}
+
+// GetLimitValue return the limit value
+func (m MaxAge) GetLimitValue() interface{} {
+    return m.Age
+}
+
+// DateFormat Test that the obj is time in the layout if type is string
+func (v *Validation) DateFormat(obj interface{}, layout string, key string, errDesc string) *Result {
+    return v.apply(DateFormat{layout, key}, obj, errDesc)
+}
+
+// Before Test that the obj is before the time expression if type is time.Time or string
+func (v *Validation) Before(obj interface{}, expr TimeExpr, key string, errDesc string) *Result {
+    return v.apply(Before{expr, key}, obj, errDesc)
+}
+
+// After Test that the obj is after the time expression if type is time.Time or string
+func (v *Validation) After(obj interface{}, expr TimeExpr, key string, errDesc string) *Result {
+    return v.apply(After{expr, key}, obj, errDesc)
+}
+
+// Between Test that the obj is between the time expressions if type is time.Time or string
+func (v *Validation) Between(obj interface{}, from, to TimeExpr, key string, errDesc string) *Result {
+    return v.apply(Between{from, to, key}, obj, errDesc)
+}
+
+// MinAge Test that the age of the birthday is at least age years if type is time.Time or string
+func (v *Validation) MinAge(obj interface{}, age int, key string, errDesc string) *Result {
+    return v.apply(MinAge{age, key}, obj, errDesc)
+}
+
+// MaxAge Test that the age of the birthday is at most age years if type is time.Time or string
+func (v *Validation) MaxAge(obj interface{}, age int, key string, errDesc string) *Result {
+    return v.apply(MaxAge{age, key}, obj, errDesc)
+}
@@ -0,0 +1,171 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "testing"
+    "time"
+)
+
+// setTestClock fix the clock at 2024-02-29 10:30:00 local time until the test ends
+func setTestClock(t *testing.T) time.Time {
+    fixed := time.Date(2024, 2, 29, 10, 30, 0, 0, time.Local)
+    SetClock(func() time.Time {
+        return fixed
+    })
+    t.Cleanup(func() {
+        SetClock(nil)
+    })
+    return fixed
+}
+
+func TestParseTimeExpr(t *testing.T) {
+    fixed := setTestClock(t)
+    today := time.Date(2024, 2, 29, 0, 0, 0, 0, time.Local)
+    tests := []struct {
+        expr string
+        ok   bool
+        want time.Time
+    }{
+        {"now", true, fixed},
+        {"today", true, today},
+        {"now+30d", true, fixed.AddDate(0, 0, 30)},
+        {"now - 2h", true, fixed.Add(-2 * time.Hour)},
+        {"now+15m", true, fixed.Add(15 * time.Minute)},
+        {"now+10s", true, fixed.Add(10 * time.Second)},
+        {"today-18y", true, time.Date(2006, 3, 1, 0, 0, 0, 0, time.Local)},
+        {"today+1mo", true, time.Date(2024, 3, 29, 0, 0, 0, 0, time.Local)},
+        {"today+2w", true, time.Date(2024, 3, 14, 0, 0, 0, 0, time.Local)},
+        {"2020-01-02", true, time.Date(2020, 1, 2, 0, 0, 0, 0, time.Local)},
+        {"2020-01-02 15:04:05", true, time.Date(2020, 1, 2, 15, 4, 5, 0, time.Local)},
+        {"now+30x", false, time.Time{}},
+        {"now+d", false, time.Time{}},
+        {"now30d", false, time.Time{}},
+        {"yesterday", false, time.Time{}},
+        {"", false, time.Time{}},
+    }
+    for _, test := range tests {
+        e, err := ParseTimeExpr(test.expr)
+        if (err == nil) != test.ok {
+            t.Errorf("ParseTimeExpr(%q) error = %v, want ok %v", test.expr, err, test.ok)
+            continue
+        }
+        if test.ok && !e.Time().Equal(test.want) {
+            t.Errorf("ParseTimeExpr(%q).Time() = %v, want %v", test.expr, e.Time(), test.want)
+        }
+    }
+}
+
+func TestRelativeTime(t *testing.T) {
+    fixed := setTestClock(t)
+    tests := []struct {
+        name string
+        obj  interface{}
+        from string
+        to   string
+        want bool
+    }{
+        {"Before", fixed.Add(-time.Second), "now", "", true},
+        {"Before", fixed, "now", "", false},
+        {"Before", "2024-02-28", "today", "", true},
+        {"Before", "2024-02-29", "today", "", false},
+        {"Before", "not a time", "now", "", false},
+        {"Before", time.Time{}, "now", "", false},
+        {"After", fixed.Add(time.Second), "now", "", true},
+        {"After", fixed, "now", "", false},
+        {"After", "2024-03-31", "now+30d", "", true},
+        {"After", "2024-03-30", "now+30d", "", false},
+        {"Between", "2024-02-29", "today", "now+7d", true},
+        {"Between", "2024-03-07 10:30:00", "today", "now+7d", true},
+        {"Between", "2024-03-07 10:30:01", "today", "now+7d", false},
+        {"Between", "2024-02-28 23:59:59", "today", "now+7d", false},
+        {"Between", &fixed, "today", "now+7d", true},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        from, err := ParseTimeExpr(test.from)
+        if err != nil {
+            t.Fatal(err)
+        }
+        var r *Result
+        switch test.name {
+        case "Before":
+            r = valid.Before(test.obj, from, "time.Before", "")
+        case "After":
+            r = valid.After(test.obj, from, "time.After", "")
+        case "Between":
+            to, err := ParseTimeExpr(test.to)
+            if err != nil {
+                t.Fatal(err)
+            }
+            r = valid.Between(test.obj, from, to, "time.Between", "")
+        }
+        if r.Ok != test.want {
+            t.Errorf("%s(%v, %s %s) should be %v", test.name, test.obj, test.from, test.to, test.want)
+        }
+    }
+}
+
+func TestAge(t *testing.T) {
+    setTestClock(t)
+    tests := []struct {
+        birthday string
+        min      int
+        max      int
+        want     bool
+    }{
+        {"2006-02-28", 18, 60, true},
+        {"2006-02-29", 18, 60, false},
+        {"2006-03-01", 18, 60, false},
+        {"1964-02-29", 18, 60, true},
+        {"1963-02-28", 18, 60, false},
+        {"2024-02-29", 0, 0, true},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        ok := valid.MinAge(test.birthday, test.min, "age.MinAge", "").Ok &&
+            valid.MaxAge(test.birthday, test.max, "age.MaxAge", "").Ok
+        if ok != test.want {
+            t.Errorf("the age of %s in [%d, %d] should be %v", test.birthday, test.min, test.max, test.want)
+        }
+    }
+}
+
+func TestRelativeTimeTag(t *testing.T) {
+    setTestClock(t)
+    type user struct {
+        Birthday string    `valid:"DateFormat(2006-01-02);MinAge(18)"`
+        ExpireAt time.Time `valid:"Between(now,now+1y)"`
+    }
+    tests := []struct {
+        u    user
+        want string
+    }{
+        {user{"2000-01-01", time.Date(2024, 6, 1, 0, 0, 0, 0, time.Local)}, ""},
+        {user{"2010-01-01", time.Date(2024, 6, 1, 0, 0, 0, 0, time.Local)}, "Birthday.MinAge"},
+        {user{"2000-01-01", time.Date(2025, 6, 1, 0, 0, 0, 0, time.Local)}, "ExpireAt.Between"},
+    }
+    for _, test := range tests {
+        valid := Validation{}
+        valid.Valid(test.u)
+        key := ""
+        if valid.HasErrors() {
+            key = valid.Errors[0].Key
+        }
+        if key != test.want {
+            t.Errorf("Valid(%v) error key = %s, want %s", test.u, key, test.want)
+        }
+    }
+}
@@ -0,0 +1,229 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "fmt"
+    "strings"
+    "unicode"
+    "unicode/utf8"
+)
+
+// UnicodeAlpha check the string only contains letters of any language
+type UnicodeAlpha struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (u UnicodeAlpha) IsSatisfied(obj interface{}) bool {
+    return allRunes(obj, unicode.IsLetter)
+}
+
+// DefaultMessage return the default UnicodeAlpha error message
+func (u UnicodeAlpha) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["UnicodeAlpha"], fetchFieldName(u.Key))
+}
+
+// GetKey return the u.Key
+func (u UnicodeAlpha) GetKey() string {
+    return u.Key
+}
+
+// GetLimitValue return the limit value
+func (u UnicodeAlpha) GetLimitValue() interface{} {
+    return nil
+}
+
+// UnicodeAlphaNumeric check the string only contains letters and digits of any language
+type UnicodeAlphaNumeric struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (u UnicodeAlphaNumeric) IsSatisfied(obj interface{}) bool {
+    return allRunes(obj, func(r rune) bool {
+        return unicode.IsLetter(r) || unicode.IsDigit(r)
+    })
+}
+
+// DefaultMessage return the default UnicodeAlphaNumeric error message
+func (u UnicodeAlphaNumeric) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["UnicodeAlphaNumeric"], fetchFieldName(u.Key))
+}
+
+// GetKey return the u.Key
+func (u UnicodeAlphaNumeric) GetKey() string {
+    return u.Key
+}
+
+// GetLimitValue return the limit value
+func (u UnicodeAlphaNumeric) GetLimitValue() interface{} {
+    return nil
+}
+
+// Han check the string only contains CJK ideographs
+type Han struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (h Han) IsSatisfied(obj interface{}) bool {
+    return allRunes(obj, func(r rune) bool {
+        return unicode.Is(unicode.Han, r)
+    })
+}
+
+// DefaultMessage return the default Han error message
+func (h Han) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["Han"], fetchFieldName(h.Key))
+}
+
+// GetKey return the h.Key
+func (h Han) GetKey() string {
+    return h.Key
+}
+
+// GetLimitValue return the limit value
+func (h Han) GetLimitValue() interface{} {
+    return nil
+}
+
+// the middle dots used in the chinese transliteration of foreign names, such as 阿凡提·买买提
+func isNameDot(r rune) bool {
+    return r == '·' || r == '•' || r == '・'
+}
+
+// ChineseName check the string is a chinese name, which only contains CJK ideographs
+// and the middle dot, at least 2 characters, the dot can not be the first or last character
+// and can not be consecutive
+type ChineseName struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (c ChineseName) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    if !ok || utf8.RuneCountInString(str) < 2 {
+        return false
+    }
+    last := '·'
+    for _, r := range str {
+        if isNameDot(r) {
+            if isNameDot(last) {
+                return false
+            }
+        } else if !unicode.Is(unicode.Han, r) {
+            return false
+        }
+        last = r
+    }
+    return !isNameDot(last)
+}
+
+// DefaultMessage return the default ChineseName error message
+func (c ChineseName) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["ChineseName"], fetchFieldName(c.Key))
+}
+
+// GetKey return the c.Key
+func (c ChineseName) GetKey() string {
+    return c.Key
+}
+
+// GetLimitValue return the limit value
+func (c ChineseName) GetLimitValue() interface{} {
+    return nil
+}
+
+// Scripts the unicode scripts of Script, such as Han, Latin, Common (digits, punctuations and spaces),
+// see unicode.Scripts
+type Scripts struct {
+    Names  []string
+    tables []*unicode.RangeTable
+}
+
+// ParseScripts return the scripts of the names, such as ParseScripts("Han", "Latin")
+func ParseScripts(names ...string) (s Scripts, err error) {
+    for _, name := range names {
+        name = strings.TrimSpace(name)
+        if len(name) == 0 {
+            continue
+        }
+        table, ok := unicode.Scripts[name]
+        if !ok {
+            return Scripts{}, fmt.Errorf("doesn't exist %s script", name)
+        }
+        s.Names = append(s.Names, name)
+        s.tables = append(s.tables, table)
+    }
+    if len(s.Names) == 0 {
+        err = fmt.Errorf("Script require at least 1 scripts")
+    }
+    return
+}
+
+// Script check every character of the string belongs to one of the scripts
+type Script struct {
+    Scripts Scripts
+    Key     string
+}
+
+// IsSatisfied judge whether obj is valid
+func (s Script) IsSatisfied(obj interface{}) bool {
+    return allRunes(obj, func(r rune) bool {
+        return unicode.In(r, s.Scripts.tables...)
+    })
+}
+
+// DefaultMessage return the default Script error message
+func (s Script) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["Script"], fetchFieldName(s.Key), strings.Join(s.Scripts.Names, ","))
+}
+
+// GetKey return the s.Key
+func (s Script) GetKey() string {
+    return s.Key
+}
+
+// GetLimitValue return the limit value
+func (s Script) GetLimitValue() interface{} {
+    return s.Scripts.Names
+}
+
+// UnicodeAlpha Test that the obj only contains letters of any language if type is string
+func (v *Validation) UnicodeAlpha(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(UnicodeAlpha{key}, obj, errDesc)
+}
+
+// UnicodeAlphaNumeric Test that the obj only contains letters and digits of any language if type is string
+func (v *Validation) UnicodeAlphaNumeric(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(UnicodeAlphaNumeric{key}, obj, errDesc)
+}
+
+// Han Test that the obj only contains CJK ideographs if type is string
+func (v *Validation) Han(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(Han{key}, obj, errDesc)
+}
+
+// ChineseName Test that the obj is a chinese name if type is string
+func (v *Validation) ChineseName(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(ChineseName{key}, obj, errDesc)
+}
+
+// Script Test that every character of the obj belongs to one of the unicode scripts if type is string,
+// the scripts are parsed by ParseScripts
+func (v *Validation) Script(obj interface{}, scripts Scripts, key string, errDesc string) *Result {
+    return v.apply(Script{scripts, key}, obj, errDesc)
+}
@@ -0,0 +1,131 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "testing"
+)
+
+func TestUnicodeLetters(t *testing.T) {
+    tests := []struct {
+        name string
+        obj  interface{}
+        want bool
+    }{
+        {"UnicodeAlpha", "beego", true},
+        {"UnicodeAlpha", "中文", true},
+        {"UnicodeAlpha", "Ελληνικά", true},
+        {"UnicodeAlpha", "abc1", false},
+        {"UnicodeAlpha", "a b", false},
+        {"UnicodeAlpha", 1, false},
+        {"UnicodeAlphaNumeric", "beego2", true},
+        {"UnicodeAlphaNumeric", "中文１２", true},
+        {"UnicodeAlphaNumeric", "abc_1", false},
+        {"Han", "中文", true},
+        {"Han", "中文a", false},
+        {"Han", "ひらがな", false},
+        {"ChineseName", "张三", true},
+        {"ChineseName", "阿凡提·买买提", true},
+        {"ChineseName", "阿凡提•买买提", true},
+        {"ChineseName", "张", false},
+        {"ChineseName", "·张三", false},
+        {"ChineseName", "张三·", false},
+        {"ChineseName", "阿凡提··买买提", false},
+        {"ChineseName", "张 三", false},
+        {"ChineseName", "张3", false},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        var r *Result
+        switch test.name {
+        case "UnicodeAlpha":
+            r = valid.UnicodeAlpha(test.obj, "name.UnicodeAlpha", "")
+        case "UnicodeAlphaNumeric":
+            r = valid.UnicodeAlphaNumeric(test.obj, "name.UnicodeAlphaNumeric", "")
+        case "Han":
+            r = valid.Han(test.obj, "name.Han", "")
+        case "ChineseName":
+            r = valid.ChineseName(test.obj, "name.ChineseName", "")
+        }
+        if r.Ok != test.want {
+            t.Errorf("%s(%v) should be %v", test.name, test.obj, test.want)
+        }
+    }
+}
+
+func TestParseScripts(t *testing.T) {
+    tests := []struct {
+        names []string
+        ok    bool
+    }{
+        {[]string{"Han"}, true},
+        {[]string{"Han", " Latin ", "Common"}, true},
+        {nil, false},
+        {[]string{""}, false},
+        {[]string{"Chinese"}, false},
+        {[]string{"han"}, false},
+    }
+    for _, test := range tests {
+        if _, err := ParseScripts(test.names...); (err == nil) != test.ok {
+            t.Errorf("ParseScripts(%v) error = %v, want ok %v", test.names, err, test.ok)
+        }
+    }
+}
+
+func TestScript(t *testing.T) {
+    tests := []struct {
+        obj     interface{}
+        scripts []string
+        want    bool
+    }{
+        {"中文", []string{"Han"}, true},
+        {"中文abc", []string{"Han"}, false},
+        {"中文abc", []string{"Han", "Latin"}, true},
+        {"中文 abc, 123", []string{"Han", "Latin"}, false},
+        {"中文 abc, 123", []string{"Han", "Latin", "Common"}, true},
+        {"ひらがな", []string{"Hiragana"}, true},
+        {"カタカナ", []string{"Hiragana"}, false},
+        {"", []string{"Han"}, true},
+        {1, []string{"Han"}, false},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        scripts, err := ParseScripts(test.scripts...)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if valid.Script(test.obj, scripts, "name.Script", "").Ok != test.want {
+            t.Errorf("Script(%v, %v) should be %v", test.obj, test.scripts, test.want)
+        }
+    }
+}
+
+func TestScriptTag(t *testing.T) {
+    type user struct {
+        Name string `valid:"Script(Han,Latin)"`
+    }
+    valid := Validation{}
+    valid.Valid(user{"张三 Zhang"})
+    if !valid.HasErrors() || valid.Errors[0].Message != "Name 只能包含 Han,Latin 文字" {
+        t.Errorf("the space should be invalid, errors = %v", valid.Errors)
+    }
+
+    type invalid struct {
+        Name string `valid:"Script(Han,Chinese)"`
+    }
+    if err := (&Validation{}).Valid(invalid{}); err == nil || err.Error() != "doesn't exist Chinese script" {
+        t.Errorf("the unknown script should be reported by Valid, error = %v", err)
+    }
+}
@@ -0,0 +1,448 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "encoding/base64"
+    "fmt"
+    "mime"
+    "net/netip"
+    "net/url"
+    "strings"
+
+    "golang.org/x/net/idna"
+)
+
+func parseURL(obj interface{}) (*url.URL, bool) {
+    str, ok := obj.(string)
+    if !ok || len(str) == 0 || strings.ContainsAny(str, " \t\r\n") {
+        return nil, false
+    }
+    u, err := url.Parse(str)
+    // the authority must have a valid host if it has one, such as http://:80 and http://a_b.com are invalid
+    if err != nil || (len(u.Host) > 0 && !isURLHost(u.Hostname())) {
+        return nil, false
+    }
+    return u, true
+}
+
+// isURLHost judge whether host is an IP address without zone, or a host name which can be an IDN host name
+func isURLHost(host string) bool {
+    if len(host) == 0 {
+        return false
+    }
+    if addr, err := netip.ParseAddr(host); err == nil {
+        return addr.Zone() == ""
+    }
+    ascii, err := idna.Lookup.ToASCII(host)
+    return err == nil && isHostname(ascii)
+}
+
+// isScheme judge whether s is a url scheme by RFC 3986, such as https or svn+ssh
+func isScheme(s string) bool {
+    for i := 0; i < len(s); i++ {
+        c := s[i]
+        switch {
+        case 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z':
+        case i > 0 && ('0' <= c && c <= '9' || c == '+' || c == '-' || c == '.'):
+        default:
+            return false
+        }
+    }
+    return len(s) > 0
+}
+
+// isFQDN judge whether s is a host name with at least 2 labels, and the top level domain is not numeric
+func isFQDN(s string) bool {
+    s = strings.TrimSuffix(s, ".")
+    if !isHostname(s) {
+        return false
+    }
+    index := strings.LastIndex(s, ".")
+    if index == -1 {
+        return false
+    }
+    for _, c := range s[index + 1:] {
+        if c < '0' || '9' < c {
+            return true
+        }
+    }
+    return false
+}
+
+// matchHost judge whether host match the pattern, the pattern can start with *. to match all the sub domains
+func matchHost(host, pattern string) bool {
+    host = strings.ToLower(strings.TrimSuffix(host, "."))
+    pattern = strings.ToLower(pattern)
+    if strings.HasPrefix(pattern, "*.") {
+        return strings.HasSuffix(host, pattern[1:])
+    }
+    return host == pattern
+}
+
+// URLOptions the allowed schemes and hosts of URL, all the schemes and hosts are allowed if they are empty
+// Options in the tag, such as URL(https,host=example.com,host=*.example.com):
+//   SCHEME       the scheme must be one of them, such as http and https
+//   host=HOST    the host must match one of them, the host can start with *. to match all the sub domains
+type URLOptions struct {
+    Schemes []string
+    Hosts   []string
+}
+
+// ParseURLOptions parse the options of URL in the tag
+func ParseURLOptions(options ...string) (opts URLOptions, err error) {
+    for _, option := range options {
+        option = strings.TrimSpace(option)
+        switch {
+        case option == "":
+        case strings.HasPrefix(option, "host="):
+            host := strings.TrimSpace(option[len("host="):])
+            if !isURLHost(strings.TrimPrefix(host, "*.")) {
+                return opts, fmt.Errorf("invalid URL host: %s", host)
+            }
+            opts.Hosts = append(opts.Hosts, host)
+        case isScheme(option):
+            opts.Schemes = append(opts.Schemes, option)
+        default:
+            return opts, fmt.Errorf("invalid URL option: %s", option)
+        }
+    }
+    return
+}
+
+// URL check the string is an absolute url with a valid host, such as https://example.com/path
+// the scheme and the host must be one of the Options if they are not empty
+type URL struct {
+    Options URLOptions
+    Key     string
+}
+
+// IsSatisfied judge whether obj is valid
+func (u URL) IsSatisfied(obj interface{}) bool {
+    pu, ok := parseURL(obj)
+    if !ok || len(pu.Scheme) == 0 || len(pu.Hostname()) == 0 {
+        return false
+    }
+    if len(u.Options.Schemes) > 0 {
+        ok = false
+        for _, scheme := range u.Options.Schemes {
+            if strings.EqualFold(scheme, pu.Scheme) {
+                ok = true
+                break
+            }
+        }
+        if !ok {
+            return false
+        }
+    }
+    if len(u.Options.Hosts) == 0 {
+        return true
+    }
+    for _, host := range u.Options.Hosts {
+        if matchHost(pu.Hostname(), host) {
+            return true
+        }
+    }
+    return false
+}
+
+// DefaultMessage return the default URL error message
+func (u URL) DefaultMessage() string {
+    schemes, hosts := strings.Join(u.Options.Schemes, ","), strings.Join(u.Options.Hosts, ",")
+    switch {
+    case len(schemes) > 0 && len(hosts) > 0:
+        return fmt.Sprintf(MessageTmpls["URLSchemeHost"], fetchFieldName(u.Key), schemes, hosts)
+    case len(schemes) > 0:
+        return fmt.Sprintf(MessageTmpls["URLScheme"], fetchFieldName(u.Key), schemes)
+    case len(hosts) > 0:
+        return fmt.Sprintf(MessageTmpls["URLHost"], fetchFieldName(u.Key), hosts)
+    }
+    return fmt.Sprintf(MessageTmpls["URL"], fetchFieldName(u.Key))
+}
+
+// GetKey return the u.Key
+func (u URL) GetKey() string {
+    return u.Key
+}
+
+// GetLimitValue return the allowed schemes and hosts
+func (u URL) GetLimitValue() interface{} {
+    return u.Options
+}
+
+// URLHost check the string is an absolute url, and the host is one of Hosts, it is same as URL(host=...),
+// the host can start with *. to allow all the sub domains, such as *.example.com
+type URLHost struct {
+    Hosts []string
+    Key   string
+}
+
+// IsSatisfied judge whether obj is valid
+func (u URLHost) IsSatisfied(obj interface{}) bool {
+    pu, ok := parseURL(obj)
+    if !ok || len(pu.Scheme) == 0 || len(pu.Hostname()) == 0 {
+        return false
+    }
+    return URL{URLOptions{Hosts: u.Hosts}, u.Key}.IsSatisfied(obj)
+}
+
+// DefaultMessage return the default URLHost error message
+func (u URLHost) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["URLHost"], fetchFieldName(u.Key), strings.Join(u.Hosts, ","))
+}
+
+// GetKey return the u.Key
+func (u URLHost) GetKey() string {
+    return u.Key
+}
+
+// GetLimitValue return the allowed hosts
+func (u URLHost) GetLimitValue() interface{} {
+    return u.Hosts
+}
+
+// URI check the string is an absolute uri, such as mailto:someone@example.com
+type URI struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (u URI) IsSatisfied(obj interface{}) bool {
+    pu, ok := parseURL(obj)
+    return ok && len(pu.Scheme) > 0
+}
+
+// DefaultMessage return the default URI error message
+func (u URI) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["URI"], fetchFieldName(u.Key))
+}
+
+// GetKey return the u.Key
+func (u URI) GetKey() string {
+    return u.Key
+}
+
+// GetLimitValue return the limit value
+func (u URI) GetLimitValue() interface{} {
+    return nil
+}
+
+// RelativeURL check the string is a relative url without scheme and host, such as /path?q=1
+type RelativeURL struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (r RelativeURL) IsSatisfied(obj interface{}) bool {
+    pu, ok := parseURL(obj)
+    return ok && len(pu.Scheme) == 0 && len(pu.Host) == 0
+}
+
+// DefaultMessage return the default RelativeURL error message
+func (r RelativeURL) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["RelativeURL"], fetchFieldName(r.Key))
+}
+
+// GetKey return the r.Key
+func (r RelativeURL) GetKey() string {
+    return r.Key
+}
+
+// GetLimitValue return the limit value
+func (r RelativeURL) GetLimitValue() interface{} {
+    return nil
+}
+
+// Hostname check the string is a host name by RFC 1123
+type Hostname struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (h Hostname) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    return ok && isHostname(str)
+}
+
+// DefaultMessage return the default Hostname error message
+func (h Hostname) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["Hostname"], fetchFieldName(h.Key))
+}
+
+// GetKey return the h.Key
+func (h Hostname) GetKey() string {
+    return h.Key
+}
+
+// GetLimitValue return the limit value
+func (h Hostname) GetLimitValue() interface{} {
+    return nil
+}
+
+// FQDN check the string is a fully qualified domain name, such as www.example.com
+type FQDN struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (f FQDN) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    return ok && isFQDN(str)
+}
+
+// DefaultMessage return the default FQDN error message
+func (f FQDN) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["FQDN"], fetchFieldName(f.Key))
+}
+
+// GetKey return the f.Key
+func (f FQDN) GetKey() string {
+    return f.Key
+}
+
+// GetLimitValue return the limit value
+func (f FQDN) GetLimitValue() interface{} {
+    return nil
+}
+
+// DomainWithTLD check the string is a domain name, and the top level domain only contains
+// at least 2 letters, or is an IDN top level domain which start with xn--
+type DomainWithTLD struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (d DomainWithTLD) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    if !ok || !isFQDN(str) {
+        return false
+    }
+    str = strings.TrimSuffix(str, ".")
+    tld := strings.ToLower(str[strings.LastIndex(str, ".") + 1:])
+    if strings.HasPrefix(tld, "xn--") {
+        return true
+    }
+    return len(tld) >= 2 && Alpha{}.IsSatisfied(tld)
+}
+
+// DefaultMessage return the default DomainWithTLD error message
+func (d DomainWithTLD) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["DomainWithTLD"], fetchFieldName(d.Key))
+}
+
+// GetKey return the d.Key
+func (d DomainWithTLD) GetKey() string {
+    return d.Key
+}
+
+// GetLimitValue return the limit value
+func (d DomainWithTLD) GetLimitValue() interface{} {
+    return nil
+}
+
+// DataURI check the string is a data uri by RFC 2397, such as data:image/png;base64,iVBORw0KGgo=,
+// the media type can be omitted, such as data:,hello or data:;charset=utf-8,hello
+type DataURI struct {
+    Key string
+}
+
+// IsSatisfied judge whether obj is valid
+func (d DataURI) IsSatisfied(obj interface{}) bool {
+    str, ok := obj.(string)
+    if !ok || !strings.HasPrefix(str, "data:") {
+        return false
+    }
+    index := strings.Index(str, ",")
+    if index == -1 {
+        return false
+    }
+    header, data := str[len("data:"):index], str[index + 1:]
+    isBase64 := false
+    if strings.HasSuffix(header, ";base64") {
+        header = strings.TrimSuffix(header, ";base64")
+        isBase64 = true
+    }
+    // the empty media type is text/plain, but the parameters can be kept, such as ;charset=utf-8
+    if strings.HasPrefix(header, ";") {
+        header = "text/plain" + header
+    }
+    if len(header) > 0 {
+        if _, _, err := mime.ParseMediaType(header); err != nil {
+            return false
+        }
+    }
+    if isBase64 {
+        _, err := base64.StdEncoding.DecodeString(data)
+        return err == nil
+    }
+    _, err := url.PathUnescape(data)
+    return err == nil
+}
+
+// DefaultMessage return the default DataURI error message
+func (d DataURI) DefaultMessage() string {
+    return fmt.Sprintf(MessageTmpls["DataURI"], fetchFieldName(d.Key))
+}
+
+// GetKey return the d.Key
+func (d DataURI) GetKey() string {
+    return d.Key
+}
+
+// GetLimitValue return the limit value
+func (d DataURI) GetLimitValue() interface{} {
+    return nil
+}
+
+// URL Test that the obj is absolute url if type is string, the scheme and the host must be one of the options
+// if they are not empty, such as URL(https,host=*.example.com), see URLOptions
+func (v *Validation) URL(obj interface{}, options URLOptions, key string, errDesc string) *Result {
+    return v.apply(URL{options, key}, obj, errDesc)
+}
+
+// URLHost Test that the obj is absolute url and the host is one of hosts if type is string
+func (v *Validation) URLHost(obj interface{}, hosts []string, key string, errDesc string) *Result {
+    return v.apply(URLHost{hosts, key}, obj, errDesc)
+}
+
+// URI Test that the obj is absolute uri if type is string
+func (v *Validation) URI(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(URI{key}, obj, errDesc)
+}
+
+// RelativeURL Test that the obj is relative url if type is string
+func (v *Validation) RelativeURL(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(RelativeURL{key}, obj, errDesc)
+}
+
+// Hostname Test that the obj is host name if type is string
+func (v *Validation) Hostname(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(Hostname{key}, obj, errDesc)
+}
+
+// FQDN Test that the obj is fully qualified domain name if type is string
+func (v *Validation) FQDN(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(FQDN{key}, obj, errDesc)
+}
+
+// DomainWithTLD Test that the obj is domain name with a letter top level domain if type is string
+func (v *Validation) DomainWithTLD(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(DomainWithTLD{key}, obj, errDesc)
+}
+
+// DataURI Test that the obj is data uri if type is string
+func (v *Validation) DataURI(obj interface{}, key string, errDesc string) *Result {
+    return v.apply(DataURI{key}, obj, errDesc)
+}
@@ -0,0 +1,159 @@
+// Copyright 2014 beego Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+    "testing"
+)
+
+func TestParseURLOptions(t *testing.T) {
+    tests := []struct {
+        options []string
+        ok      bool
+    }{
+        {nil, true},
+        {[]string{"http", "https"}, true},
+        {[]string{"svn+ssh", "host=example.com", "host=*.example.com"}, true},
+        {[]string{"https", "host=例子.测试"}, true},
+        {[]string{"1http"}, false},
+        {[]string{"ht tp"}, false},
+        {[]string{"host="}, false},
+        {[]string{"host=a_b.com"}, false},
+        {[]string{"max=1"}, false},
+    }
+    for _, test := range tests {
+        if _, err := ParseURLOptions(test.options...); (err == nil) != test.ok {
+            t.Errorf("ParseURLOptions(%v) error = %v, want ok %v", test.options, err, test.ok)
+        }
+    }
+}
+
+func TestURL(t *testing.T) {
+    tests := []struct {
+        obj     interface{}
+        options []string
+        want    bool
+    }{
+        {"https://example.com/path?q=1", nil, true},
+        {"http://127.0.0.1:8080", nil, true},
+        {"http://[::1]:8080/", nil, true},
+        {"http://例子.测试/", nil, true},
+        {"http://localhost", nil, true},
+        // the host must be valid
+        {"http://a_b.com/", nil, false},
+        {"http://-a.com/", nil, false},
+        {"http://a..com/", nil, false},
+        {"http://:80", nil, false},
+        {"http://[fe80::1%25en0]/", nil, false},
+        {"/path", nil, false},
+        {"mailto:someone@example.com", nil, false},
+        {"https://example.com", []string{"http", "https"}, true},
+        {"ftp://example.com", []string{"http", "https"}, false},
+        {"HTTPS://example.com", []string{"https"}, true},
+        // the scheme and the host are combined
+        {"https://example.com", []string{"https", "host=example.com"}, true},
+        {"https://www.example.com", []string{"https", "host=*.example.com"}, true},
+        {"http://www.example.com", []string{"https", "host=*.example.com"}, false},
+        {"https://example.org", []string{"https", "host=example.com", "host=*.example.com"}, false},
+        {"https://badexample.com", []string{"host=*.example.com"}, false},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        opts, err := ParseURLOptions(test.options...)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if valid.URL(test.obj, opts, "url.URL", "").Ok != test.want {
+            t.Errorf("URL(%v, %v) should be %v", test.obj, test.options, test.want)
+        }
+    }
+}
+
+func TestURLs(t *testing.T) {
+    tests := []struct {
+        name string
+        obj  interface{}
+        want bool
+    }{
+        {"URLHost", "https://www.example.com/a", true},
+        {"URLHost", "https://a_b.example.com/a", false},
+        {"URLHost", "https://example.org/a", false},
+        {"URI", "mailto:someone@example.com", true},
+        {"URI", "urn:isbn:0451450523", true},
+        {"URI", "/path", false},
+        {"URI", "http://a_b.com", false},
+        {"RelativeURL", "/path?q=1", true},
+        {"RelativeURL", "path/to", true},
+        {"RelativeURL", "http://example.com", false},
+        {"RelativeURL", "//example.com/path", false},
+        {"DataURI", "data:image/png;base64,iVBORw0KGgo=", true},
+        {"DataURI", "data:,hello%20world", true},
+        {"DataURI", "data:;charset=utf-8,hello", true},
+        {"DataURI", "data:;base64,aGVsbG8=", true},
+        {"DataURI", "data:text/plain;charset=utf-8,hello", true},
+        {"DataURI", "data:image/png;base64,not base64", false},
+        {"DataURI", "data:;charset,hello", false},
+        {"DataURI", "data:hello", false},
+        {"DataURI", "hello", false},
+    }
+    valid := Validation{}
+    for _, test := range tests {
+        var r *Result
+        switch test.name {
+        case "URLHost":
+            r = valid.URLHost(test.obj, []string{"*.example.com"}, "url.URLHost", "")
+        case "URI":
+            r = valid.URI(test.obj, "url.URI", "")
+        case "RelativeURL":
+            r = valid.RelativeURL(test.obj, "url.RelativeURL", "")
+        case "DataURI":
+            r = valid.DataURI(test.obj, "url.DataURI", "")
+        }
+        if r.Ok != test.want {
+            t.Errorf("%s(%v) should be %v", test.name, test.obj, test.want)
+        }
+    }
+}
+
+func TestURLTag(t *testing.T) {
+    type site struct {
+        Home string `valid:"URL(https,host=example.com,host=*.example.com)"`
+    }
+    tests := []struct {
+        s       site
+        message string
+    }{
+        {site{"https://www.example.com"}, ""},
+        {site{"http://www.example.com"}, "Home 无效的url地址，协议必须是 https 中的一个，域名必须是 example.com,*.example.com 中的一个"},
+    }
+    for _, test := range tests {
+        valid := Validation{}
+        valid.Valid(test.s)
+        message := ""
+        if valid.HasErrors() {
+            message = valid.Errors[0].Message
+        }
+        if message != test.message {
+            t.Errorf("Valid(%v) error = %s, want %s", test.s, message, test.message)
+        }
+    }
+
+    type invalid struct {
+        Home string `valid:"URL(host=a_b)"`
+    }
+    if err := (&Validation{}).Valid(invalid{}); err == nil {
+        t.Error("the invalid host of URL should be reported by Valid")
+    }
+}